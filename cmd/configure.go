@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"parrot/internal/config"
+	"parrot/internal/i18n"
+	"parrot/internal/style"
 
 	"github.com/spf13/cobra"
 )
@@ -24,24 +26,25 @@ func init() {
 }
 
 func runConfigure(cmd *cobra.Command, args []string) {
-	fmt.Println("🦜 Parrot Configuration Wizard")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println()
-
-	reader := bufio.NewReader(os.Stdin)
-	
 	// Load existing config or defaults
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		cfg = config.DefaultConfig()
 	}
+	localize(cfg)
+	
+	ui.Println("🦜 Parrot Configuration Wizard")
+	ui.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	ui.Println()
+
+	reader := bufio.NewReader(os.Stdin)
 
 	// 1. Choose config location
 	configPath := chooseConfigLocation(reader)
 	
 	// 2. Configure API backend
-	fmt.Println("🌐 API Backend Configuration")
-	fmt.Println("────────────────────────────")
+	ui.Println("🌐 API Backend Configuration")
+	ui.Println("────────────────────────────")
 	cfg.API.Enabled = askYesNo(reader, "Enable API backend? (recommended)", cfg.API.Enabled)
 	
 	if cfg.API.Enabled {
@@ -64,8 +67,8 @@ func runConfigure(cmd *cobra.Command, args []string) {
 	}
 	
 	// 3. Configure Local backend
-	fmt.Println("\n🖥️  Local Backend Configuration")
-	fmt.Println("────────────────────────────")
+	ui.Println("\n🖥️  Local Backend Configuration")
+	ui.Println("────────────────────────────")
 	cfg.Local.Enabled = askYesNo(reader, "Enable local Ollama backend?", cfg.Local.Enabled)
 	
 	if cfg.Local.Enabled {
@@ -81,55 +84,69 @@ func runConfigure(cmd *cobra.Command, args []string) {
 	}
 	
 	// 4. General preferences
-	fmt.Println("\n⚙️  General Preferences")
-	fmt.Println("────────────────────────")
+	ui.Println("\n⚙️  General Preferences")
+	ui.Println("────────────────────────")
 	personalities := []string{"mild", "sarcastic", "savage"}
 	if cfg.General.Personality == "" {
 		cfg.General.Personality = "sarcastic"
 	}
 	cfg.General.Personality = askChoice(reader, "Personality level", personalities, cfg.General.Personality)
+	languages := append([]string{"auto"}, i18n.Supported...)
+	if !i18n.Valid(cfg.General.Language) || cfg.General.Language == "" {
+		cfg.General.Language = "auto"
+	}
+	cfg.General.Language = askChoice(reader, "Language", languages, cfg.General.Language)
 	cfg.General.Debug = askYesNo(reader, "Enable debug mode?", cfg.General.Debug)
 	cfg.General.FallbackMode = askYesNo(reader, "Use only fallback responses? (disable AI)", cfg.General.FallbackMode)
+	if cfg.General.FallbackStyle == "" {
+		cfg.General.FallbackStyle = "lines"
+	}
+	cfg.Tips.Enabled = askYesNo(reader, "Show a fix suggestion under the roast?", cfg.Tips.Enabled)
+	cfg.General.FallbackStyle = askChoice(reader, "Offline response style", []string{"lines", "grammar", "mixed"}, cfg.General.FallbackStyle)
+	if !style.Valid(cfg.General.Style) {
+		cfg.General.Style = style.Default
+	}
+	cfg.General.Style = askChoice(reader, "Response style", style.Names, cfg.General.Style)
 	
 	// 5. Save configuration
-	fmt.Println("\n💾 Saving Configuration...")
+	ui.Println("\n💾 Saving Configuration...")
 	if err := config.CreateSampleConfig(configPath); err != nil {
-		fmt.Printf("❌ Error creating config template: %v\n", err)
+		ui.Printf("❌ Error creating config template: %v\n", err)
 		return
 	}
 	
 	// Load the template and update with user values
 	if err := saveConfig(cfg, configPath); err != nil {
-		fmt.Printf("❌ Error saving configuration: %v\n", err)
+		ui.Printf("❌ Error saving configuration: %v\n", err)
 		return
 	}
 	
-	fmt.Printf("✅ Configuration saved to: %s\n", configPath)
+	ui.Printf("✅ Configuration saved to: %s\n", configPath)
 	
 	// 6. Next steps
-	fmt.Println("\n🎯 Next Steps:")
+	ui.Println("\n🎯 Next Steps:")
 	if cfg.API.Enabled && cfg.API.APIKey != "" {
-		fmt.Println("   • Test API backend: parrot status")
+		ui.Println("   • Test API backend: parrot status")
 	}
 	if cfg.Local.Enabled {
-		fmt.Printf("   • Ensure model is available: ollama pull %s\n", cfg.Local.Model)
+		ui.Printf("   • Ensure model is available: ollama pull %s\n", cfg.Local.Model)
 	}
-	fmt.Println("   • Test parrot: parrot mock \"git push\" \"1\"")
-	fmt.Println("   • Install shell hooks: parrot install")
+	ui.Println("   • Test parrot: parrot mock \"git push\" \"1\"")
+	ui.Println("   • Install shell hooks: parrot install")
 }
 
 func chooseConfigLocation(reader *bufio.Reader) string {
-	fmt.Println("📁 Configuration Location")
-	fmt.Println("─────────────────────────")
+	ui.Println("📁 Configuration Location")
+	ui.Println("─────────────────────────")
 	
 	paths := config.GetConfigPaths()
-	fmt.Println("Choose where to save your configuration:")
+	ui.Println("Choose where to save your configuration:")
 	for i, path := range paths {
-		fmt.Printf("%d. %s\n", i+1, path)
+		ui.Printf("%d. %s\n", i+1, path)
 	}
 	
 	for {
-		fmt.Print("Choice [1]: ")
+		ui.Print("Choice [1]: ")
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
 		
@@ -142,15 +159,15 @@ func chooseConfigLocation(reader *bufio.Reader) string {
 			return paths[choice-1]
 		}
 		
-		fmt.Println("❌ Invalid choice. Please enter a number between 1 and", len(paths))
+		ui.Printf("❌ Invalid choice. Please enter a number between 1 and %d\n", len(paths))
 	}
 }
 
 func askString(reader *bufio.Reader, prompt, defaultValue string) string {
 	if defaultValue != "" {
-		fmt.Printf("%s [%s]: ", prompt, defaultValue)
+		ui.Printf("%s [%s]: ", ui.T(prompt), defaultValue)
 	} else {
-		fmt.Printf("%s: ", prompt)
+		ui.Printf("%s: ", ui.T(prompt))
 	}
 	
 	input, _ := reader.ReadString('\n')
@@ -168,7 +185,7 @@ func askYesNo(reader *bufio.Reader, prompt string, defaultValue bool) bool {
 		defaultStr = "y"
 	}
 	
-	fmt.Printf("%s [%s]: ", prompt, defaultStr)
+	ui.Printf("%s [%s]: ", ui.T(prompt), defaultStr)
 	input, _ := reader.ReadString('\n')
 	input = strings.ToLower(strings.TrimSpace(input))
 	
@@ -176,21 +193,26 @@ func askYesNo(reader *bufio.Reader, prompt string, defaultValue bool) bool {
 		return defaultValue
 	}
 	
-	return input == "y" || input == "yes"
+	// Accept yes in the supported languages too
+	switch input {
+	case "y", "yes", "j", "ja", "s", "si", "sí", "はい":
+		return true
+	}
+	return false
 }
 
 func askChoice(reader *bufio.Reader, prompt string, choices []string, defaultValue string) string {
-	fmt.Printf("%s:\n", prompt)
+	ui.Printf("%s:\n", ui.T(prompt))
 	for i, choice := range choices {
 		marker := " "
 		if choice == defaultValue {
 			marker = "*"
 		}
-		fmt.Printf("%s %d. %s\n", marker, i+1, choice)
+		ui.Printf("%s %d. %s\n", marker, i+1, choice)
 	}
 	
 	for {
-		fmt.Print("Choice: ")
+		ui.Print("Choice: ")
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
 		
@@ -210,7 +232,7 @@ func askChoice(reader *bufio.Reader, prompt string, choices []string, defaultVal
 			}
 		}
 		
-		fmt.Printf("❌ Invalid choice. Please enter 1-%d or the option name.\n", len(choices))
+		ui.Printf("❌ Invalid choice. Please enter 1-%d or the option name.\n", len(choices))
 	}
 }
 
@@ -234,6 +256,9 @@ func generateConfigContent(cfg *config.Config) string {
 	content.WriteString("[general]\n")
 	content.WriteString(fmt.Sprintf("personality = \"%s\"\n", cfg.General.Personality))
 	content.WriteString(fmt.Sprintf("fallback_mode = %t\n", cfg.General.FallbackMode))
+	content.WriteString(fmt.Sprintf("fallback_style = \"%s\"\n", cfg.General.FallbackStyle))
+	content.WriteString(fmt.Sprintf("style = \"%s\"\n", cfg.General.Style))
+	content.WriteString(fmt.Sprintf("language = \"%s\"\n", cfg.General.Language))
 	content.WriteString(fmt.Sprintf("debug = %t\n", cfg.General.Debug))
 	content.WriteString("\n")
 	
@@ -258,6 +283,16 @@ func generateConfigContent(cfg *config.Config) string {
 	content.WriteString(fmt.Sprintf("endpoint = \"%s\"\n", cfg.Local.Endpoint))
 	content.WriteString(fmt.Sprintf("model = \"%s\"\n", cfg.Local.Model))
 	content.WriteString(fmt.Sprintf("timeout = %d\n", cfg.Local.Timeout))
+	content.WriteString("\n")
+	
+	// Tips section
+	content.WriteString("[tips]\n")
+	content.WriteString(fmt.Sprintf("enabled = %t\n", cfg.Tips.Enabled))
+	quoted := make([]string, len(cfg.Tips.Personalities))
+	for i, personality := range cfg.Tips.Personalities {
+		quoted[i] = fmt.Sprintf("%q", personality)
+	}
+	content.WriteString(fmt.Sprintf("personalities = [%s]\n", strings.Join(quoted, ", ")))
 	
 	return content.String()
 }
\ No newline at end of file
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"parrot/internal/colors"
+	"parrot/internal/exitcode"
+	"parrot/internal/fallback"
 
 	"github.com/spf13/cobra"
 )
@@ -38,21 +40,20 @@ func runDemo(cmd *cobra.Command, args []string) {
 
 	personalities := []string{"mild", "sarcastic", "savage"}
 	
+	// Keep history in memory so the demo never affects real fallbacks
+	engine := fallback.New("")
+	
 	for _, personality := range personalities {
 		fmt.Printf("🎭 %s Personality\n", personality)
 		fmt.Println("─────────────────────")
 		
 		for _, test := range commands {
-			// Use simple hardcoded responses for demo
-			var response string
-			switch personality {
-			case "mild":
-				response = getDemoResponse(test.cmdType, "mild")
-			case "sarcastic":
-				response = getDemoResponse(test.cmdType, "sarcastic")  
-			case "savage":
-				response = getDemoResponse(test.cmdType, "savage")
-			}
+			code, _ := exitcode.Parse(test.exitCode)
+			response := engine.Pick(fallback.Situation{
+				Personality: personality,
+				Type:        test.cmdType,
+				Exit:        exitcode.Interpret(code, ""),
+			})
 			
 			fmt.Printf("Command: %s\n", test.cmd)
 			fmt.Printf("  Simple:   %s\n", colors.FormatParrotOutput(personality, response, false))
@@ -69,37 +70,3 @@ func runDemo(cmd *cobra.Command, args []string) {
 		fmt.Println("   💡 To enable colors, ensure you're in a terminal and NO_COLOR is not set")
 	}
 }
-
-func getDemoResponse(cmdType, personality string) string {
-	responses := map[string]map[string]string{
-		"mild": {
-			"git":     "Git command failed. Maybe check your remote branch?",
-			"nodejs":  "NPM seems unhappy. Try clearing your cache?",
-			"docker":  "Container seems upset. Check your Dockerfile?",
-			"http":    "Request didn't go through. Check the URL?",
-			"generic": "Command didn't work as expected. Check the syntax?",
-		},
-		"sarcastic": {
-			"git":     "Another git genius who forgot to pull first. Classic.",
-			"nodejs":  "NPM install failed? Shocking! Nobody saw that coming.",
-			"docker":  "Docker container more like docker DISASTER!",
-			"http":    "404: Competence not found.",
-			"generic": "Wow, you managed to break something simple. Impressive!",
-		},
-		"savage": {
-			"git":     "Git rejected your code harder than everyone rejects you.",
-			"nodejs":  "NPM refuses to install anything for someone this incompetent.",
-			"docker":  "Your containers crash faster than your career prospects.",
-			"http":    "The internet collectively rejected you. Impressive.",
-			"generic": "Your command failed harder than you failed at life.",
-		},
-	}
-	
-	if personalityMap, exists := responses[personality]; exists {
-		if response, exists := personalityMap[cmdType]; exists {
-			return response
-		}
-		return personalityMap["generic"]
-	}
-	return "Error: Unknown personality"
-}
\ No newline at end of file
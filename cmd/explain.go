@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/exitcode"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <exit-code> [command]",
+	Short: "Explain what an exit code means",
+	Long:  "Decode an exit code, including 128+N signals and tool-specific meanings when a command is given",
+	Args:  cobra.RangeArgs(1, 2),
+	Run:   explainExitCode,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+func explainExitCode(cmd *cobra.Command, args []string) {
+	code, err := exitcode.Parse(args[0])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	var executable string
+	if len(args) > 1 {
+		executable = cmdline.Parse(args[1]).FailedSegment().Executable()
+	}
+
+	info := exitcode.Interpret(code, executable)
+
+	fmt.Printf("🦜 Exit code %d\n", code)
+	fmt.Printf("   • Meaning: %s\n", info.Meaning)
+	fmt.Printf("   • Class: %s\n", info.Class)
+	if info.Signal != "" {
+		fmt.Printf("   • Signal: %s (%d)\n", info.Signal, code-128)
+	}
+	if info.Tool != "" {
+		fmt.Printf("   • Specific to: %s\n", info.Tool)
+	} else if executable != "" {
+		fmt.Printf("   • No %s-specific meaning known; using shell conventions\n", executable)
+	}
+	if info.Benign {
+		fmt.Println("   • Usually expected behaviour rather than a real failure")
+	}
+
+	// Mention the generic reading when a tool overrides it
+	if info.Tool != "" {
+		generic := exitcode.Interpret(code, "")
+		if generic.Meaning != info.Meaning {
+			fmt.Printf("   • Elsewhere this usually means: %s\n", generic.Meaning)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/colors"
+	"parrot/internal/config"
+	"parrot/internal/llm"
+	"parrot/internal/packs"
+	"parrot/internal/prompts"
+	"parrot/internal/safety"
+	"parrot/internal/session"
+	"parrot/internal/tips"
+
+	"github.com/spf13/cobra"
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "Suggest and run a corrected version of the last failed command",
+	Long: `Suggest a corrected version of the last command that failed in this shell,
+then run it after you accept or edit it. Suggestions come from the built-in tip
+rules first and the configured model backends second. Destructive commands are
+never run, whoever suggested them.`,
+	Args: cobra.NoArgs,
+	Run:  fixCommand,
+}
+
+var (
+	fixYes   bool
+	fixPrint bool
+)
+
+func init() {
+	fixCmd.Flags().BoolVarP(&fixYes, "yes", "y", false, "Run the suggestion without asking")
+	fixCmd.Flags().BoolVar(&fixPrint, "print", false, "Only print the suggested command (for shell key bindings)")
+	rootCmd.AddCommand(fixCmd)
+}
+
+// fixTimeout bounds how long fix waits for a model suggestion; the user asked
+// for it, so it can wait longer than a roast
+const fixTimeout = 15 * time.Second
+
+// stateBuiltins change the shell itself, which a child process can't do
+var stateBuiltins = map[string]bool{
+	"cd": true, "pushd": true, "popd": true, "export": true, "unset": true,
+	"source": true, ".": true, "alias": true, "unalias": true, "set": true,
+}
+
+// fixVoice is what the parrot says while fixing, per personality
+type fixVoice struct {
+	offer  string // Introduces the suggestion
+	fixed  string // The command succeeded
+	failed string // The command failed; formatted with the exit code
+}
+
+var fixVoices = map[string]fixVoice{
+	"mild": {
+		offer:  "Here's a fix that might work:",
+		fixed:  "That worked. Nice one!",
+		failed: "Still failing (exit %d). Worth a closer look.",
+	},
+	"sarcastic": {
+		offer:  "Allow me, since you clearly won't:",
+		fixed:  "It worked. You're welcome.",
+		failed: "Still broken (exit %d). Even I have limits.",
+	},
+	"savage": {
+		offer:  "Here. Try not to mess this one up too:",
+		fixed:  "Fixed. By me. Remember that.",
+		failed: "Still broken (exit %d). Impressive, in a way.",
+	},
+}
+
+func fixCommand(cmd *cobra.Command, args []string) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	path := session.LastFailurePath()
+	record, err := session.LoadLastFailure(path)
+	if err != nil {
+		fixFail("❌ %v", err)
+		return
+	}
+	if record == nil {
+		fixFail("🦜 Nothing to fix: no failed command recorded in this shell yet.")
+		return
+	}
+
+	f := failureFromRecord(cfg, record)
+	suggestion, source := suggestFix(cfg, f)
+	if suggestion == "" {
+		fixFail("🦜 No fix for `%s`. You're on your own.", record.Command)
+		return
+	}
+
+	if fixPrint {
+		if reason, blocked := safety.Check(suggestion); blocked {
+			fixFail("🛑 Not suggesting `%s`: it %s.", suggestion, reason)
+			return
+		}
+		fmt.Println(suggestion)
+		return
+	}
+
+	voice := voiceFor(cfg.General.Personality)
+	printParrot(cfg, voice.offer)
+	fmt.Println(colors.FormatCommand(suggestion))
+	fmt.Printf("   %s\n", colors.Colorize(colors.Dim, "from "+source))
+
+	command := suggestion
+	if !fixYes {
+		var run bool
+		command, run = confirmFix(suggestion)
+		if !run {
+			fmt.Println("🦜 Fine. Suit yourself.")
+			return
+		}
+	}
+
+	if reason, blocked := safety.Check(command); blocked {
+		fmt.Printf("🛑 Not running this: it %s. Run it yourself if you're sure.\n", reason)
+		return
+	}
+	if segments := cmdline.Parse(command).Segments; len(segments) > 0 && stateBuiltins[segments[0].Executable()] {
+		fmt.Printf("🦜 `%s` has to change your shell, so run it yourself.\n", segments[0].Executable())
+		return
+	}
+
+	if cwd, _ := os.Getwd(); record.Cwd != "" && record.Cwd != cwd {
+		fmt.Printf("   📁 Running in %s\n", record.Cwd)
+	}
+	exitCode, err := runInShell(command, record.Cwd)
+	if err != nil {
+		fmt.Printf("❌ Could not run the command: %v\n", err)
+		return
+	}
+
+	if exitCode == 0 {
+		session.ClearLastFailure(path)
+		printParrot(cfg, voice.fixed)
+		return
+	}
+
+	// The new attempt is now the failure to fix
+	session.SaveLastFailure(path, session.Failure{
+		Command:  command,
+		ExitCode: exitCode,
+		Cwd:      record.Cwd,
+		Time:     time.Now(),
+	})
+	printParrot(cfg, fmt.Sprintf(voice.failed, exitCode))
+}
+
+// failureFromRecord rebuilds the analysed failure from the session record
+func failureFromRecord(cfg *config.Config, record *session.Failure) failure {
+	parsed := cmdline.Parse(record.Command)
+	f := failure{
+		Parsed:   parsed,
+		Type:     detectCommandType(cfg, parsed),
+		ExitCode: strconv.Itoa(record.ExitCode),
+		Exit:     interpretExitCode(cfg, strconv.Itoa(record.ExitCode), parsed.FailedSegment().Executable()),
+		Cwd:      record.Cwd,
+		Packs:    packs.InstalledLines(),
+	}
+	f.Similar = similarCommands(cfg, f)
+	f.Tip, _ = tips.Suggest(tips.Input{Parsed: parsed, Exit: f.Exit, Cwd: record.Cwd})
+	return f
+}
+
+// suggestFix returns a corrected command and where it came from, preferring
+// the offline rules, which are known to be right, over a model
+func suggestFix(cfg *config.Config, f failure) (string, string) {
+	if f.Tip.Command != "" {
+		return f.Tip.Command, f.Tip.Rule + " rule"
+	}
+
+	manager := llm.NewLLMManager(cfg)
+	request := buildRequest(cfg, f)
+	request.Prompt = prompts.BuildFixPrompt(buildPromptData(cfg, f))
+
+	if !fixPrint {
+		fmt.Print("🦜 💭\r")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fixTimeout)
+	defer cancel()
+	command, backend := manager.SuggestFix(ctx, request)
+	if !fixPrint {
+		fmt.Print("     \r")
+	}
+	return command, string(backend) + " model"
+}
+
+// confirmFix asks whether to run the suggestion, letting the user edit it first
+func confirmFix(suggestion string) (string, bool) {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print("Run it? [Y/e/n]: ")
+	input, _ := reader.ReadString('\n')
+
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "y", "yes":
+		return suggestion, true
+	case "e", "edit":
+		return askString(reader, "Command", suggestion), true
+	default:
+		return "", false
+	}
+}
+
+// posixShells understand the bash syntax fixes are written in. Other shells,
+// such as fish or nu, would choke on it.
+var posixShells = []string{"sh", "bash", "zsh", "ksh", "dash"}
+
+// runInShell runs a command through the user's shell, or sh when that isn't
+// a POSIX shell, with the terminal attached and returns its exit code
+func runInShell(command, dir string) (int, error) {
+	shell := os.Getenv("SHELL")
+	if !slices.Contains(posixShells, filepath.Base(shell)) {
+		shell = "/bin/sh"
+	}
+
+	run := exec.Command(shell, "-c", command)
+	run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		run.Dir = dir
+	}
+
+	// Ctrl-C is meant for the command; parrot stays around to report on it
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	err := run.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil // Reported the way shells do
+		}
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+func voiceFor(personality string) fixVoice {
+	if voice, exists := fixVoices[personality]; exists {
+		return voice
+	}
+	return fixVoices["sarcastic"]
+}
+
+func printParrot(cfg *config.Config, line string) {
+	if cfg.General.Colors {
+		fmt.Println(colors.FormatParrotOutput(cfg.General.Personality, line, false))
+	} else {
+		fmt.Printf("🦜 %s\n", line)
+	}
+}
+
+// fixFail reports why there is nothing to run. With --print it goes to
+// stderr so a key binding never inserts it into the command line.
+func fixFail(format string, args ...interface{}) {
+	if fixPrint {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+		os.Exit(1)
+	}
+	fmt.Printf(format+"\n", args...)
+}
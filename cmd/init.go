@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"parrot/internal/config"
+	"parrot/internal/hooks"
+	"parrot/internal/slow"
+
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init <shell>",
+	Short: "Print the shell integration for bash, zsh, fish, nushell or xonsh",
+	Long: `Print the hook code that reports failed commands to parrot. Load it from
+your shell's startup file:
+
+  eval "$(parrot init bash)"   # ~/.bashrc
+  eval "$(parrot init zsh)"    # ~/.zshrc
+  parrot init fish | source    # ~/.config/fish/conf.d/parrot.fish
+  execx($(parrot init xonsh))  # ~/.xonshrc
+
+nushell can't load generated code at startup; 'parrot install' saves
+'parrot init nu' to parrot.nu and sources that from config.nu.
+
+The code calls this binary by its absolute path and is regenerated every time
+the shell starts, so it always matches the installed version.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: hooks.Shells(),
+	Run:       initShell,
+}
+
+var (
+	initAsync  bool
+	initStderr bool
+)
+
+func init() {
+	initCmd.Flags().BoolVar(&initAsync, "async", false, "Mock failures in the background so the prompt never waits")
+	initCmd.Flags().BoolVar(&initStderr, "stderr", false, "Print roasts on stderr instead of stdout")
+	rootCmd.AddCommand(initCmd)
+}
+
+func initShell(cmd *cobra.Command, args []string) {
+	script, err := hooks.Script(args[0], hookOptions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(script)
+}
+
+// hookOptions describes this binary and its configuration to the generated
+// hook code
+func hookOptions() hooks.Options {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	return hooks.Options{
+		Binary:       parrotBinary(),
+		Version:      rootCmd.Version,
+		Async:        initAsync,
+		Stderr:       initStderr,
+		NoTranscript: !cfg.Session.Transcript,
+		SlowAfter:    slowAfter(cfg),
+	}
+}
+
+// slowAfter returns the shortest slow command threshold in whole seconds, so
+// the hooks don't start parrot for successes that can't be slow
+func slowAfter(cfg *config.Config) int {
+	checker, _ := slow.New(cfg.Slow)
+	return int(checker.Shortest().Seconds())
+}
+
+// parrotBinary returns the absolute path of the running binary, or plain
+// "parrot" to find it on $PATH when that isn't known
+func parrotBinary() string {
+	executable, err := os.Executable()
+	if err != nil {
+		return "parrot"
+	}
+	if absolute, err := filepath.Abs(executable); err == nil {
+		executable = absolute
+	}
+	// `go run` builds into a temporary directory that is gone afterwards
+	if strings.Contains(executable, string(filepath.Separator)+"go-build") {
+		return "parrot"
+	}
+	return executable
+}
@@ -3,23 +3,43 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"parrot/internal/hooks"
+
 	"github.com/spf13/cobra"
 )
 
 var installCmd = &cobra.Command{
 	Use:   "install",
 	Short: "Install parrot shell hooks",
-	Long:  "Adds parrot hooks to your shell configuration",
-	Run:   installHooks,
+	Long: `Adds a block loading ` + "`parrot init <shell>`" + ` to your shell's startup file.
+The block is fenced with "# >>> parrot >>>" markers, so running install again
+updates it in place. The file is backed up to <file>.parrot-backup before it
+is changed.
+
+The shell comes from $SHELL; use --shell for others, e.g. --shell bash,zsh.`,
+	Run: installHooks,
 }
 
+var installShells []string
+
 func init() {
+	installCmd.Flags().BoolVar(&initAsync, "async", false, "Mock failures in the background so the prompt never waits")
+	installCmd.Flags().BoolVar(&initStderr, "stderr", false, "Print roasts on stderr instead of stdout")
+	installCmd.Flags().StringSliceVar(&installShells, "shell", nil, "Shells to install for (default: $SHELL): "+strings.Join(hooks.Shells(), ", "))
 	rootCmd.AddCommand(installCmd)
 }
 
+// startupFile is a shell startup file that loads the hooks
+type startupFile struct {
+	Shell string
+	Path  string
+	Owned bool // The whole file is parrot's, so it is replaced rather than edited
+}
+
 func installHooks(cmd *cobra.Command, args []string) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -27,84 +47,219 @@ func installHooks(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Detect shell and appropriate RC file
-	shell := os.Getenv("SHELL")
-	var rcFile string
-	
-	if filepath.Base(shell) == "zsh" {
-		rcFile = filepath.Join(homeDir, ".zshrc")
-	} else {
-		rcFile = filepath.Join(homeDir, ".bashrc")
-	}
-
-	// Try standard installation paths for the hook script
-	var hookPath string
-	possiblePaths := []string{
-		"/usr/share/parrot/parrot-hook.sh",     // RPM installation
-		"/usr/local/share/parrot/parrot-hook.sh", // Manual installation
-		"./parrot-hook.sh",                     // Development
-	}
-	
-	for _, path := range possiblePaths {
-		if _, err := os.Stat(path); err == nil {
-			hookPath = path
-			break
+	shells := installShells
+	if len(shells) == 0 {
+		shell := detectShell()
+		if name := filepath.Base(os.Getenv("SHELL")); os.Getenv("SHELL") != "" && name != shell {
+			fmt.Printf("⚠️  Parrot has no hooks for %s; installing the bash ones (see 'parrot init --help').\n", name)
 		}
+		shells = []string{shell}
 	}
-	
-	if hookPath == "" {
-		fmt.Println("❌ Hook script not found. Searched in:")
-		for _, path := range possiblePaths {
-			fmt.Printf("   - %s\n", path)
+	for _, shell := range shells {
+		if !hooks.Supported(shell) {
+			fmt.Printf("❌ Unsupported shell %q (supported: %s)\n", shell, strings.Join(hooks.Shells(), ", "))
+			return
 		}
-		fmt.Println("Make sure parrot is properly installed.")
-		return
 	}
 
-	// Add source line and environment setup to RC file
-	sourceLine := fmt.Sprintf("source \"%s\"", hookPath)
-	
-	fmt.Printf("🦜 Installing parrot hooks to: %s\n", rcFile)
-	fmt.Printf("📝 Adding hook: %s\n", sourceLine)
 	fmt.Printf("🔧 Configuring Ollama for better performance\n")
-	
-	// Check if already installed
-	if isAlreadyInstalled(rcFile, sourceLine) {
-		fmt.Println("✅ Parrot hooks already installed!")
-		return
+	for _, shell := range shells {
+		if !installShell(shell, homeDir) {
+			return
+		}
 	}
-	
-	// Append to RC file
-	file, err := os.OpenFile(rcFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Printf("❌ Error opening %s: %v\n", rcFile, err)
-		return
+
+	// Point out other shells on this machine that don't load the hooks yet
+	if len(installShells) == 0 {
+		for _, shell := range hooks.Shells() {
+			if shell != shells[0] && shellInstalled(shell) && !hooksInstalled(shell, homeDir) {
+				fmt.Printf("💡 %s is installed too; run 'parrot install --shell %s' to hook it as well.\n", shell, shell)
+			}
+		}
+	}
+}
+
+// installShell adds or updates the parrot block in each of shell's startup
+// files, reporting whether that worked
+func installShell(shell, homeDir string) bool {
+	files := startupFiles(shell, homeDir)
+	initLine := hooks.InitLine(shell, hookOptions())
+	if hooks.Saved(shell) {
+		scriptPath := savedScriptPath(files[0].Path)
+		if err := saveHookScript(shell, scriptPath); err != nil {
+			fmt.Printf("❌ Error writing %s: %v\n", scriptPath, err)
+			return false
+		}
+		fmt.Printf("📄 Saved hook code to: %s (run 'parrot install' again after upgrading)\n", scriptPath)
+		initLine = hooks.SourceLine(shell, scriptPath)
+	}
+	block := hooks.Fence(installBlock(shell, initLine))
+
+	for _, file := range files {
+		fmt.Printf("🦜 Installing parrot hooks to: %s\n", file.Path)
+		fmt.Printf("📝 Adding hook: %s\n", initLine)
+
+		existing, err := os.ReadFile(file.Path)
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Printf("❌ Error reading %s: %v\n", file.Path, err)
+			return false
+		}
+		updated := block
+		if !file.Owned {
+			updated = hooks.Upsert(string(existing), block)
+		}
+		if updated == string(existing) {
+			fmt.Println("✅ Parrot hooks already installed!")
+			continue
+		}
+		if hooks.HasBlock(string(existing)) {
+			fmt.Println("🔁 Updating the parrot block already in the file")
+		}
+
+		if err := writeStartupFile(file, existing, updated); err != nil {
+			fmt.Printf("❌ Error writing to %s: %v\n", file.Path, err)
+			return false
+		}
+		fmt.Println("✅ Parrot hooks installed successfully!")
+	}
+
+	fmt.Printf("🔄 Run '%s' to activate, or start a new shell session.\n", reloadHint(shell, files[0].Path))
+	return true
+}
+
+// writeStartupFile replaces a startup file's content, keeping a copy of what
+// was there before unless parrot owns the file
+func writeStartupFile(file startupFile, existing []byte, content string) error {
+	if existing != nil && !file.Owned {
+		backup := file.Path + ".parrot-backup"
+		if err := os.WriteFile(backup, existing, 0600); err != nil {
+			return fmt.Errorf("backing up to %s: %w", backup, err)
+		}
+		fmt.Printf("💾 Backed up %s to %s\n", file.Path, backup)
+	}
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file and writes through symlinks,
+	// so dotfiles managed elsewhere stay where they are
+	return os.WriteFile(file.Path, []byte(content), 0644)
+}
+
+// installBlock is what goes between the markers in a shell's startup file
+func installBlock(shell, initLine string) string {
+	switch shell {
+	case "fish":
+		return fmt.Sprintf(`set -gx OLLAMA_KEEP_ALIVE 1h  # Keep AI models loaded for better performance
+if status is-interactive
+    %s
+end
+`, initLine)
+	case "nu":
+		return fmt.Sprintf(`$env.OLLAMA_KEEP_ALIVE = "1h"  # Keep AI models loaded for better performance
+%s
+`, initLine)
+	case "xonsh":
+		return fmt.Sprintf(`$OLLAMA_KEEP_ALIVE = "1h"  # Keep AI models loaded for better performance
+%s
+`, initLine)
 	}
-	defer file.Close()
-	
-	installContent := fmt.Sprintf(`
-# Parrot CLI hooks and configuration
-export OLLAMA_KEEP_ALIVE="1h"  # Keep AI models loaded for better performance
+	return fmt.Sprintf(`export OLLAMA_KEEP_ALIVE="1h"  # Keep AI models loaded for better performance
 %s
-`, sourceLine)
-	
-	_, err = file.WriteString(installContent)
+`, initLine)
+}
+
+// saveHookScript writes the hook code for shells that source it from a file
+func saveHookScript(shell, path string) error {
+	script, err := hooks.Script(shell, hookOptions())
 	if err != nil {
-		fmt.Printf("❌ Error writing to %s: %v\n", rcFile, err)
-		return
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
 	}
-	
-	fmt.Println("✅ Parrot hooks installed successfully!")
-	fmt.Println("🔄 Run 'source ~/.bashrc' (or ~/.zshrc) to activate, or start a new shell session.")
+	return os.WriteFile(path, []byte(script), 0644)
 }
 
-func isAlreadyInstalled(rcFile, sourceLine string) bool {
-	content, err := os.ReadFile(rcFile)
-	if err != nil {
-		return false
+// detectShell returns the user's shell, from $SHELL. Shells parrot has no
+// hooks for get bash's.
+func detectShell() string {
+	if shell := filepath.Base(os.Getenv("SHELL")); hooks.Supported(shell) {
+		return shell
+	}
+	return "bash"
+}
+
+// shellInstalled reports whether shell is on $PATH
+func shellInstalled(shell string) bool {
+	_, err := exec.LookPath(shell)
+	return err == nil
+}
+
+// hooksInstalled reports whether shell's startup files already load parrot
+func hooksInstalled(shell, homeDir string) bool {
+	for _, file := range startupFiles(shell, homeDir) {
+		content, err := os.ReadFile(file.Path)
+		if err != nil || !hooks.HasBlock(string(content)) {
+			return false
+		}
+	}
+	return true
+}
+
+// startupFiles returns the files the hooks go in for shell, the main one
+// first. bash login shells, which macOS terminals start, read .bash_profile
+// instead of .bashrc, so it gets the hooks too unless it sources .bashrc.
+func startupFiles(shell, homeDir string) []startupFile {
+	switch shell {
+	case "zsh":
+		dir := os.Getenv("ZDOTDIR")
+		if dir == "" {
+			dir = homeDir
+		}
+		return []startupFile{{Shell: shell, Path: filepath.Join(dir, ".zshrc")}}
+	case "fish":
+		return []startupFile{{Shell: shell, Path: filepath.Join(configDir(homeDir), "fish", "conf.d", "parrot.fish"), Owned: true}}
+	case "nu":
+		return []startupFile{{Shell: shell, Path: filepath.Join(configDir(homeDir), "nushell", "config.nu")}}
+	case "xonsh":
+		return []startupFile{{Shell: shell, Path: filepath.Join(homeDir, ".xonshrc")}}
+	}
+
+	files := []startupFile{{Shell: "bash", Path: filepath.Join(homeDir, ".bashrc")}}
+	for _, name := range []string{".bash_profile", ".bash_login"} {
+		path := filepath.Join(homeDir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if !strings.Contains(string(content), ".bashrc") {
+			files = append(files, startupFile{Shell: "bash", Path: path})
+		}
+		break // bash only reads the first one it finds
+	}
+	return files
+}
+
+// savedScriptPath is where the hook code goes for shells that source it from
+// a file, next to the startup file
+func savedScriptPath(startupFile string) string {
+	return filepath.Join(filepath.Dir(startupFile), "parrot.nu")
+}
+
+// configDir is where XDG shells keep their configuration
+func configDir(homeDir string) string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
 	}
-	
-	// Check for both the source line and OLLAMA_KEEP_ALIVE setting
-	contentStr := string(content)
-	return strings.Contains(contentStr, sourceLine) && strings.Contains(contentStr, "OLLAMA_KEEP_ALIVE")
-}
\ No newline at end of file
+	return filepath.Join(homeDir, ".config")
+}
+
+// reloadHint is the command that loads freshly installed hooks in the
+// current shell
+func reloadHint(shell, rcFile string) string {
+	switch shell {
+	case "fish", "nu", "xonsh":
+		return "exec " + shell
+	}
+	return "source " + rcFile
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"parrot/internal/config"
+	"parrot/internal/i18n"
+)
+
+// ui prints user-facing messages in the configured language
+var ui = i18n.NewPrinter(i18n.English)
+
+// localize switches ui to the language the configuration asks for
+func localize(cfg *config.Config) {
+	ui = i18n.NewPrinter(i18n.Resolve(cfg.General.Language))
+}
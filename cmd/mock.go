@@ -3,15 +3,29 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"parrot/internal/classify"
+	"parrot/internal/cmdline"
 	"parrot/internal/colors"
 	"parrot/internal/config"
+	"parrot/internal/exitcode"
+	"parrot/internal/fallback"
+	"parrot/internal/i18n"
 	"parrot/internal/llm"
+	"parrot/internal/packs"
+	"parrot/internal/policy"
 	"parrot/internal/prompts"
+	"parrot/internal/ratelimit"
+	"parrot/internal/redact"
+	"parrot/internal/session"
+	"parrot/internal/slow"
+	"parrot/internal/style"
+	"parrot/internal/suppress"
+	"parrot/internal/tips"
 
 	"github.com/spf13/cobra"
 )
@@ -41,72 +55,521 @@ var mockCmd = &cobra.Command{
 	Run:   mockCommand,
 }
 
+var (
+	mockDryRun     bool
+	mockPipestatus string
+	mockDuration   time.Duration
+)
+
 func init() {
+	mockCmd.Flags().BoolVar(&mockDryRun, "dry-run", false, "Explain classification, policy and prompt without contacting any backend")
+	mockCmd.Flags().DurationVar(&mockDuration, "duration", 0, "How long the command ran, e.g. 1500ms; successes that ran too long get a comment")
+	mockCmd.Flags().StringVar(&mockPipestatus, "pipestatus", "", "Exit status of each pipeline stage, e.g. \"1 0\" from bash's ${PIPESTATUS[*]}")
 	rootCmd.AddCommand(mockCmd)
 }
 
+// failure describes the failed command being mocked
+type failure struct {
+	Parsed   *cmdline.Command
+	Type     string
+	ExitCode string
+	Exit     exitcode.Info
+	Cwd      string
+	Tip      tips.Tip // Offline fix suggestion, if a rule found one
+	Similar  []string // Known commands near a name that wasn't found
+	Streak   session.Streak
+	Tone     string        // Configured personality, before a streak escalated it
+	Duration time.Duration // How long the command ran; zero if unknown
+	Slow     slow.Decision
+	Packs    []packs.Line // Installed pack lines, loaded once per run
+}
+
+// streakEntries is how much of the session transcript a streak is looked for in
+const streakEntries = 100
+
 func mockCommand(cmd *cobra.Command, args []string) {
 	failedCmd := args[0]
 	exitCode := args[1]
 	
-	// Basic command type detection
-	cmdType := detectCommandType(failedCmd)
+	// Load configuration, falling back to defaults if the file is broken
+	cfg, err := config.LoadConfig()
+	configLoaded := err == nil
+	if !configLoaded {
+		cfg = config.DefaultConfig()
+	}
+	
+	// Parse the command line, classify the part that most likely failed and
+	// interpret the exit code in the context of that tool
+	parsed := cmdline.Parse(failedCmd)
+	failedCode := applyPipestatus(cfg, parsed, exitCode)
+	f := failure{
+		Parsed:   parsed,
+		Type:     detectCommandType(cfg, parsed),
+		ExitCode: exitCode,
+		Exit:     interpretExitCode(cfg, failedCode, parsed.FailedSegment().Executable()),
+		Packs:    packs.InstalledLines(),
+	}
+	f.Cwd, _ = os.Getwd()
+	f.Similar = similarCommands(cfg, f)
+	
+	// A losing streak sharpens the tone, and a success is only worth a word
+	// when it ends one or took far too long
+	f.Streak = detectStreak(cfg, f)
+	f.Duration = mockDuration
+	f.Slow = checkSlow(cfg, f)
+	success := f.Exit.Class == exitcode.ClassSuccess
+	if success && !worthMentioning(cfg, f) && !mockDryRun {
+		if cfg.General.Debug {
+			fmt.Println("✅ Succeeded without ending a streak or taking too long; nothing to say")
+		}
+		return
+	}
+	f.Tone = cfg.General.Personality
+	cfg.General.Personality = f.Streak.Escalate(f.Tone, cfg.Streak)
+	if cfg.General.Debug && cfg.General.Personality != f.Tone {
+		fmt.Printf("📈 Tone escalated from %s to %s\n", f.Tone, cfg.General.Personality)
+	}
+	if cfg.Tips.EnabledFor(cfg.General.Personality) {
+		f.Tip, _ = tips.Suggest(tips.Input{Parsed: parsed, Exit: f.Exit, Cwd: f.Cwd})
+	}
+	
+	// Initialize LLM manager and build the request with a context-aware prompt
+	manager := llm.NewLLMManager(cfg)
+	request := buildRequest(cfg, f)
+	suppression := checkSuppression(cfg, f)
+	
+	if mockDryRun {
+		explainDryRun(cfg, manager, f, request, suppression)
+		return
+	}
+	
+	// Keep quiet about expected and ignored failures, which still go in the
+	// history so stats count them
+	if suppression.Suppressed {
+		recordHistory(cfg, f, suppression)
+		if cfg.General.Debug {
+			fmt.Printf("🤐 Suppressed: rule %q (%s)\n", suppression.Rule, suppression.Reason)
+		}
+		return
+	}
+	
+	// Remember the failure so `parrot fix` can offer a corrected command, or
+	// forget it once the command works
+	recordFailure(cfg, f)
+	
+	// Respect policies that forbid any response before printing anything
+	if decision := manager.Decide(request); decision.Action == policy.ActionSilent {
+		recordHistory(cfg, f, suppress.Decision{Suppressed: true, Rule: "policy " + decision.Rule, Reason: decision.Reason})
+		if cfg.General.Debug {
+			fmt.Printf("🤐 Staying silent: policy %q (%s)\n", decision.Rule, decision.Reason)
+		}
+		return
+	}
+	
+	// Give the user a break when they asked for one or were roasted recently
+	if limit := checkRateLimit(cfg); !limit.Allowed {
+		recordHistory(cfg, f, suppress.Decision{Suppressed: true, Rule: "rate limit", Reason: limit.Reason})
+		if cfg.General.Debug {
+			fmt.Printf("🤐 Staying silent: %s\n", limit.Reason)
+		}
+		return
+	}
+	recordHistory(cfg, f, suppression)
 	
 	// Show immediate feedback to user
 	fmt.Print("🦜 ")
 	
 	// Generate a smart mock response
-	response, cfg := generateSmartResponse(cmdType, failedCmd, exitCode)
+	var response string
+	if configLoaded {
+		response = generateSmartResponse(cfg, manager, f, request)
+	} else {
+		response = getFallbackResponse(cfg, f)
+	}
 	
 	// Clear the loading indicator and show response
 	fmt.Print("\r") // Clear current line
 	
-	// Format output with colors and personality
-	if cfg.General.Colors {
-		fmt.Println(colors.FormatParrotOutput(cfg.General.Personality, response, cfg.General.Enhanced))
-	} else {
-		fmt.Printf("🦜 %s\n", response)
+	// Prefer a rule's tip, which is known to be right, over the model's
+	response, tip := tips.SplitResponse(response)
+	if f.Tip.Text != "" {
+		tip = f.Tip.Text
+	}
+	if !cfg.Tips.EnabledFor(cfg.General.Personality) || success {
+		tip = ""
+	}
+	
+	// Format output with colors and personality, laid out for the response style
+	layout := style.Get(cfg.General.Style).Layout
+	fmt.Println(colors.FormatResponse(cfg.General.Personality, response, layout, cfg.General.Enhanced, cfg.General.Colors))
+	if tip != "" {
+		if cfg.General.Colors {
+			fmt.Println(colors.FormatTip(tip))
+		} else {
+			fmt.Printf("   💡 %s\n", tip)
+		}
 	}
 }
 
-func detectCommandType(command string) string {
-	parts := strings.Fields(command)
-	if len(parts) == 0 {
-		return "unknown"
-	}
-	
-	switch parts[0] {
-	case "git":
-		return "git"
-	case "npm", "yarn", "pnpm":
-		return "nodejs"
-	case "docker", "docker-compose":
-		return "docker"
-	case "curl", "wget":
-		return "http"
-	case "ssh":
-		return "ssh"
-	case "cd":
-		return "navigation"
+func detectCommandType(cfg *config.Config, parsed *cmdline.Command) string {
+	classifier, err := classify.New(cfg.Classifier.Rules)
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	
+	cmdType := classifier.ClassifyParsed(parsed)
+	if cfg.General.Debug {
+		if parsed.IsCompound() {
+			fmt.Printf("🔗 Likely failed segment: %s\n", parsed.FailedSegment().Raw)
+		}
+		fmt.Printf("🏷️  Command type: %s\n", cmdType)
+	}
+	return cmdType
+}
+
+// applyPipestatus maps the per-stage statuses passed by the hooks onto the
+// parsed pipeline, so the stage that failed is the one blamed. It returns the
+// exit code to interpret: that stage's, or the command line's own.
+func applyPipestatus(cfg *config.Config, parsed *cmdline.Command, exitCode string) string {
+	if mockPipestatus == "" {
+		return exitCode
+	}
+	codes, err := cmdline.ParsePipestatus(mockPipestatus)
+	if err != nil {
+		if cfg.General.Debug {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+		return exitCode
+	}
+	code, err := exitcode.Parse(exitCode)
+	if err != nil || !parsed.ApplyPipestatus(codes, code) {
+		return exitCode
+	}
+	
+	if cfg.General.Debug {
+		fmt.Printf("🚰 Pipeline status: %s\n", formatStages(parsed.Stages()))
+	}
+	return strconv.Itoa(*parsed.FailedSegment().Status)
+}
+
+// formatStages renders pipeline stages with their exit statuses
+func formatStages(stages []cmdline.Segment) string {
+	parts := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		parts = append(parts, fmt.Sprintf("%s → %d", stage.Raw, *stage.Status))
+	}
+	return strings.Join(parts, " | ")
+}
+
+func interpretExitCode(cfg *config.Config, exitCode, executable string) exitcode.Info {
+	code, err := exitcode.Parse(exitCode)
+	if err != nil {
+		if cfg.General.Debug {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+		return exitcode.Info{Class: exitcode.ClassFailure, Meaning: "unknown exit status"}
+	}
+	
+	info := exitcode.Interpret(code, executable)
+	if cfg.General.Debug {
+		fmt.Printf("🔢 Exit code %s [%s]\n", info.Summary(), info.Class)
+	}
+	return info
+}
+
+// buildRequest builds the context-aware prompt and the backend request for a
+// failure, or for a success that ended a streak or took too long
+func buildRequest(cfg *config.Config, f failure) llm.Request {
+	command := f.Parsed.Raw
+	data := buildPromptData(cfg, f)
+	var prompt prompts.Prompt
+	switch {
+	case f.Exit.Class != exitcode.ClassSuccess:
+		prompt = prompts.BuildPrompt(f.Type, cfg.General.Personality, data)
+	case redeemed(cfg, f):
+		prompt = prompts.BuildRedemptionPrompt(cfg.General.Personality, data)
 	default:
-		return "generic"
+		prompt = prompts.BuildSlowPrompt(cfg.General.Personality, data)
+	}
+	
+	return llm.Request{
+		Prompt:      prompt,
+		Command:     command,
+		Parsed:      f.Parsed,
+		Cwd:         f.Cwd,
+		CommandType: f.Type,
+		Exit:        f.Exit,
+		Similar:     f.Similar,
+		Attempts:    f.Streak.Attempts,
+		Stuck:       f.Streak.Stuck,
+		Duration:    f.Duration,
+		Slow:        slowSuccess(cfg, f),
+		Packs:       f.Packs,
 	}
 }
 
-func generateSmartResponse(cmdType, command, exitCode string) (string, *config.Config) {
-	// Load configuration
-	cfg, err := config.LoadConfig()
+// buildPromptData gathers the failure context shared by roast and fix prompts
+func buildPromptData(cfg *config.Config, f failure) prompts.PromptData {
+	command := f.Parsed.Raw
+	success := f.Exit.Class == exitcode.ClassSuccess
+	
+	data := prompts.PromptData{
+		Command:    command,
+		ExitCode:   f.ExitCode,
+		Transcript: loadTranscript(cfg, f),
+		Parsed:     f.Parsed,
+		Exit:       &f.Exit,
+		Tip:        !success && cfg.Tips.EnabledFor(cfg.General.Personality) && f.Tip.Text == "",
+		Similar:    f.Similar,
+		Format:     style.Get(cfg.General.Style).Instructions,
+		Language:   i18n.Name(i18n.Resolve(cfg.General.Language)),
+		Streak:     f.Streak.Failures,
+		Attempts:   f.Streak.Attempts,
+		Stuck:      f.Streak.Stuck,
+		Duration:   f.Duration,
+		Slow:       f.Slow.Slow,
+	}
+	// Pack lines are roasts; they make poor examples of a congratulation
+	if !success {
+		data.Examples = packs.Examples(f.Packs, cfg.General.Personality, f.Type, f.Exit, 3)
+	}
+	return data
+}
+
+// shellStatus is the status the shell reported for the whole command line,
+// as the transcript has it, which may not be the failed pipeline stage's
+func shellStatus(f failure) int {
+	status, err := exitcode.Parse(f.ExitCode)
 	if err != nil {
-		// If config loading fails, use fallback with default config
-		defaultCfg := config.DefaultConfig()
-		return getFallbackResponse(cmdType), defaultCfg
+		return f.Exit.Code
+	}
+	return status
+}
+
+// detectStreak works out how the session has been going from its transcript
+func detectStreak(cfg *config.Config, f failure) session.Streak {
+	if !cfg.Streak.Enabled {
+		return session.Streak{}
 	}
 	
-	// Initialize LLM manager
-	manager := llm.NewLLMManager(cfg)
+	command := f.Parsed.Raw
+	entries, err := session.LoadTranscript(session.TranscriptPath(), streakEntries)
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  Transcript unavailable: %v\n", err)
+	}
+	preceding := session.Preceding(entries, command, shellStatus(f))
+	streak := session.CurrentStreak(preceding, command, f.Exit.Class != exitcode.ClassSuccess, time.Now())
+	if cfg.General.Debug && streak != (session.Streak{}) {
+		fmt.Printf("🔥 Streak: %s\n", describeStreak(streak))
+	}
+	return streak
+}
+
+// redeemed reports whether a success put an end to enough failed attempts to
+// be congratulated
+func redeemed(cfg *config.Config, f failure) bool {
+	return cfg.Streak.Redemption > 0 && f.Streak.Attempts >= cfg.Streak.Redemption
+}
+
+// slowSuccess reports whether a success gets a comment on the wait rather
+// than a congratulation
+func slowSuccess(cfg *config.Config, f failure) bool {
+	return f.Exit.Class == exitcode.ClassSuccess && f.Slow.Slow && !redeemed(cfg, f)
+}
+
+// worthMentioning reports whether a success gets any response
+func worthMentioning(cfg *config.Config, f failure) bool {
+	return redeemed(cfg, f) || f.Slow.Slow
+}
+
+// checkSlow decides whether the command ran longer than its threshold
+func checkSlow(cfg *config.Config, f failure) slow.Decision {
+	checker, err := slow.New(cfg.Slow)
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	decision := checker.Check(slow.Input{
+		Command:  f.Parsed.Raw,
+		Parsed:   f.Parsed,
+		Type:     f.Type,
+		Duration: f.Duration,
+	})
+	if cfg.General.Debug && decision.Slow {
+		fmt.Printf("🐢 Slow: ran %s, %s\n", ratelimit.Round(f.Duration), describeThreshold(decision))
+	}
+	return decision
+}
+
+// describeThreshold renders which slow threshold applied, for debug and
+// dry-run output
+func describeThreshold(decision slow.Decision) string {
+	threshold := fmt.Sprintf("threshold %s", decision.Threshold)
+	if decision.Threshold == 0 {
+		threshold = "never slow"
+	}
+	if decision.Rule != "" {
+		threshold += fmt.Sprintf(" (rule %q: %s)", decision.Rule, decision.Reason)
+	}
+	return threshold
+}
+
+// describeStreak renders a streak for debug and dry-run output
+func describeStreak(streak session.Streak) string {
+	var parts []string
+	if streak.Failures > 0 {
+		parts = append(parts, fmt.Sprintf("failures in a row: %d", streak.Failures))
+	}
+	if streak.Attempts > 0 {
+		parts = append(parts, fmt.Sprintf("failed attempts at this command: %d", streak.Attempts))
+	}
+	if streak.Stuck > 0 {
+		parts = append(parts, fmt.Sprintf("stuck for %s", ratelimit.Round(streak.Stuck)))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// similarCommands finds the commands a "command not found" failure most likely
+// meant, scanning $PATH, builtins and the aliases passed in by the hooks
+func similarCommands(cfg *config.Config, f failure) []string {
+	segment := f.Parsed.FailedSegment()
+	if f.Exit.Code != 127 || len(segment.Args) == 0 || strings.Contains(segment.Args[0], "/") {
+		return nil
+	}
 	
-	// Build context-aware prompt with personality
-	prompt := prompts.BuildPrompt(cmdType, command, exitCode, cfg.General.Personality)
+	similar := tips.SimilarCommands(segment.Args[0])
+	if cfg.General.Debug && len(similar) > 0 {
+		fmt.Printf("🔤 Similar commands: %s\n", strings.Join(similar, ", "))
+	}
+	return similar
+}
+
+// checkSuppression decides whether the failure gets no response at all
+func checkSuppression(cfg *config.Config, f failure) suppress.Decision {
+	suppressor, err := suppress.New(cfg.Suppress)
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	return suppressor.Check(suppress.Input{
+		Command: f.Parsed.Raw,
+		Parsed:  f.Parsed,
+		Cwd:     f.Cwd,
+		Exit:    f.Exit,
+	})
+}
+
+// checkRateLimit decides whether the frequency settings and any snooze allow
+// a roast now, and if so records it. Problems with the state file never keep
+// the parrot quiet.
+func checkRateLimit(cfg *config.Config) ratelimit.Decision {
+	limiter := ratelimit.New(cfg.Frequency)
+	decision := ratelimit.Decision{Allowed: true}
+	err := ratelimit.Update(ratelimit.Path(), func(state *ratelimit.State) error {
+		decision = limiter.Allow(state, os.Getenv("PARROT_SESSION_ID"), time.Now())
+		return nil
+	})
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	return decision
+}
+
+// explainDryRun reports what mock would do without contacting any backend
+func explainDryRun(cfg *config.Config, manager *llm.LLMManager, f failure, request llm.Request, suppression suppress.Decision) {
+	fmt.Println("🦜 Dry run - nothing will be sent anywhere")
+	fmt.Printf("   • Command: %s\n", f.Parsed.Raw)
+	if f.Parsed.IsCompound() {
+		fmt.Printf("   • Likely failed segment: %s\n", f.Parsed.FailedSegment().Raw)
+	}
+	if stages := f.Parsed.Stages(); len(stages) > 0 {
+		fmt.Printf("   • Pipeline status: %s\n", formatStages(stages))
+	}
+	fmt.Printf("   • Type: %s\n", f.Type)
+	fmt.Printf("   • Exit code: %s [%s]\n", f.Exit.Summary(), f.Exit.Class)
+	if cfg.Streak.Enabled {
+		fmt.Printf("   • Streak: %s\n", describeStreak(f.Streak))
+	}
+	if f.Duration > 0 {
+		fmt.Printf("   • Duration: %s, %s\n", ratelimit.Round(f.Duration), describeThreshold(f.Slow))
+	}
+	if cfg.General.Personality != f.Tone {
+		fmt.Printf("   • Tone: escalated from %s to %s\n", f.Tone, cfg.General.Personality)
+	}
+	fmt.Printf("   • Working directory: %s\n", f.Cwd)
+	if len(f.Similar) > 0 {
+		fmt.Printf("   • Similar commands: %s\n", strings.Join(f.Similar, ", "))
+	}
+	if f.Tip.Text != "" {
+		fmt.Printf("   • Tip (%s rule): %s\n", f.Tip.Rule, f.Tip.Text)
+	}
+	
+	if suppression.Suppressed {
+		fmt.Printf("   • Suppressed: rule %q (%s)\n", suppression.Rule, suppression.Reason)
+	}
+	decision := manager.Decide(request)
+	if decision.Rule != "" {
+		fmt.Printf("   • Policy: rule %q applies (%s) → %s\n", decision.Rule, decision.Reason, decision.Action)
+	} else {
+		fmt.Printf("   • Policy: no rule applies → %s\n", decision.Action)
+	}
+	
+	// Check the rate limit against a copy of the state, which isn't saved
+	limit := ratelimit.Decision{Allowed: true}
+	if state, err := ratelimit.Load(ratelimit.Path()); err == nil {
+		limit = ratelimit.New(cfg.Frequency).Allow(&state, os.Getenv("PARROT_SESSION_ID"), time.Now())
+	}
+	if limit.Allowed {
+		fmt.Println("   • Rate limit: a roast is allowed now")
+	} else {
+		fmt.Printf("   • Rate limit: %s\n", limit.Reason)
+	}
+	
+	// Show which backends would be tried
+	fmt.Println("\n⚡ Backends:")
+	switch {
+	case f.Exit.Class == exitcode.ClassSuccess && !worthMentioning(cfg, f):
+		fmt.Println("   • None - the command succeeded without ending a streak or taking too long")
+		return
+	case suppression.Suppressed, decision.Action == policy.ActionSilent, !limit.Allowed:
+		fmt.Println("   • None - the parrot stays silent")
+		return
+	case decision.Action == policy.ActionFallbackOnly:
+		fmt.Println("   • 🔄 Fallback only (policy)")
+	case cfg.General.FallbackMode:
+		fmt.Println("   • 🔄 Fallback only (fallback_mode)")
+	default:
+		if cfg.API.Enabled && cfg.API.APIKey != "" {
+			if decision.AllowsAPI() {
+				fmt.Println("   • 🌐 API")
+			} else {
+				fmt.Println("   • 🌐 API - skipped by policy")
+			}
+		}
+		if cfg.Local.Enabled {
+			fmt.Println("   • 🖥️  Local")
+		}
+		fmt.Println("   • 🔄 Fallback")
+	}
+	fmt.Printf("   • Offline style: %s\n", cfg.General.FallbackStyle)
+	fmt.Printf("   • Response style: %s\n", style.Get(cfg.General.Style).Name)
+	
+	// Show the prompt exactly as the API backend would receive it
+	prompt, masked := manager.PreviewPrompt(request.Prompt, llm.BackendAPI)
+	fmt.Println("\n📝 System message (as the API backend would see it):")
+	fmt.Println(prompt.System)
+	fmt.Println("\n📝 User message (untrusted data, escaped):")
+	fmt.Println(prompt.User)
+	if len(masked) > 0 {
+		fmt.Println("\n🔒 Masked:")
+		for _, match := range masked {
+			fmt.Printf("   • %s\n", match)
+		}
+	}
+}
+
+func generateSmartResponse(cfg *config.Config, manager *llm.LLMManager, f failure, request llm.Request) string {
 	
 	// Use a shorter overall timeout for shell responsiveness (max 2 seconds)
 	maxTimeout := 2 * time.Second
@@ -121,7 +584,7 @@ func generateSmartResponse(cmdType, command, exitCode string) (string, *config.C
 	
 	// Start generation in a goroutine
 	go func() {
-		response, backend := manager.Generate(ctx, prompt, cmdType)
+		response, backend := manager.Generate(ctx, request)
 		select {
 		case responseChan <- struct {
 			response string
@@ -149,7 +612,7 @@ func generateSmartResponse(cmdType, command, exitCode string) (string, *config.C
 				fmt.Printf("🔄 Fallback backend used\n")
 			}
 		}
-		return result.response, cfg
+		return result.response
 	case <-progressTimer.C:
 		// Show thinking indicator after 500ms
 		fmt.Print("💭")
@@ -166,50 +629,153 @@ func generateSmartResponse(cmdType, command, exitCode string) (string, *config.C
 					fmt.Printf("\n🔄 Fallback backend used\n")
 				}
 			}
-			return result.response, cfg
+			return result.response
 		case <-ctx.Done():
 			// Fallback to instant response if timeout reached
-			return getFallbackResponse(cmdType), cfg
+			return getFallbackResponse(cfg, f)
 		}
 	case <-ctx.Done():
 		// Fallback to instant response if timeout reached
-		return getFallbackResponse(cmdType), cfg
-	}
-}
-
-func getFallbackResponse(cmdType string) string {
-	fallbacks := map[string][]string{
-		"git": {
-			"Git good? More like git rekt!",
-			"Did you forget to pull again? Classic amateur move.",
-			"Another git genius strikes again!",
-		},
-		"nodejs": {
-			"NPM install failed? Shocking! Nobody saw that coming.",
-			"Your package.json is crying. Fix it.",
-			"Node modules: where dependencies go to die.",
-		},
-		"docker": {
-			"Docker container more like docker DISASTER!",
-			"Even containers can't contain your incompetence.",
-			"Your Dockerfile needs therapy.",
-		},
-		"http": {
-			"404: Competence not found.",
-			"Even the internet doesn't want to talk to you.",
-			"Connection refused? So is your logic.",
-		},
-		"generic": {
-			"Wow, you managed to break something simple. Impressive!",
-			"Maybe try reading the manual... oh wait, who am I kidding?",
-			"Error code says it all: user error!",
-		},
-	}
-	
-	responses, exists := fallbacks[cmdType]
-	if !exists {
-		responses = fallbacks["generic"]
-	}
-	
-	return responses[rand.Intn(len(responses))]
-}
\ No newline at end of file
+		return getFallbackResponse(cfg, f)
+	}
+}
+
+// recordFailure saves the failure as the session's last one, or forgets the
+// last one when this is it succeeding. Errors only matter in debug mode since
+// the record is a convenience for `parrot fix`.
+func recordFailure(cfg *config.Config, f failure) {
+	if f.Exit.Class == exitcode.ClassSuccess {
+		last, _ := session.LoadLastFailure(session.LastFailurePath())
+		if last != nil && strings.TrimSpace(last.Command) == strings.TrimSpace(f.Parsed.Raw) {
+			session.ClearLastFailure(session.LastFailurePath())
+		}
+		return
+	}
+	
+	err := session.SaveLastFailure(session.LastFailurePath(), session.Failure{
+		Command:  f.Parsed.Raw,
+		ExitCode: f.Exit.Code,
+		Cwd:      f.Cwd,
+		Time:     time.Now(),
+	})
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+}
+
+// recordHistory adds the failure to the history behind the stats; successes
+// aren't recorded. Like the last failure record, errors only matter in debug
+// mode.
+func recordHistory(cfg *config.Config, f failure, suppression suppress.Decision) {
+	if f.Exit.Class == exitcode.ClassSuccess {
+		return
+	}
+	
+	err := session.AppendHistory(session.HistoryPath(), session.Event{
+		Time:       time.Now(),
+		Command:    f.Parsed.Raw,
+		ExitCode:   f.Exit.Code,
+		Type:       f.Type,
+		Suppressed: suppression.Suppressed,
+		Rule:       suppression.Rule,
+	})
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+}
+
+// loadTranscript returns the formatted recent history of the hooked shell
+// session, excluding the failure being mocked. Any error yields no context.
+func loadTranscript(cfg *config.Config, f failure) string {
+	// Upkeep happens even without a transcript, which older sessions left behind
+	path := session.TranscriptPath()
+	redactor, _ := redact.New(cfg.Redaction.Patterns)
+	defer session.PruneSessions(path)
+	defer func() {
+		if err := session.TrimTranscript(path, redactor); err != nil && cfg.General.Debug {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+	}()
+	if !cfg.Session.Transcript {
+		return ""
+	}
+	command := f.Parsed.Raw
+	
+	// Load one extra entry since the current failure is usually the last line
+	entries, err := session.LoadTranscript(path, cfg.Session.TranscriptLimit+1)
+	if err != nil {
+		if cfg.General.Debug {
+			fmt.Printf("⚠️  Transcript unavailable: %v\n", err)
+		}
+		return ""
+	}
+	
+	entries = session.Preceding(entries, command, shellStatus(f))
+	if len(entries) > cfg.Session.TranscriptLimit {
+		entries = entries[len(entries)-cfg.Session.TranscriptLimit:]
+	}
+	
+	entries, ok := screenTranscript(cfg, f, entries)
+	if !ok {
+		return ""
+	}
+	return session.FormatTranscript(entries)
+}
+
+// screenTranscript holds the transcript to the privacy policy the failure
+// itself is held to. The prompt goes to the API when the failure may, so
+// every entry must allow that too; otherwise every entry must at least allow
+// the local model. A single entry that doesn't, such as an earlier vault
+// command or one run in a local-only directory, drops the whole transcript.
+// The entries that pass have their secrets masked.
+func screenTranscript(cfg *config.Config, f failure, entries []session.Entry) ([]session.Entry, bool) {
+	commandPolicy, _ := policy.New(cfg.Policy)
+	classifier, _ := classify.New(cfg.Classifier.Rules)
+	redactor, _ := redact.New(cfg.Redaction.Patterns)
+	
+	allowed := policy.Decision.AllowsLocal
+	current := commandPolicy.Evaluate(policy.Input{Command: f.Parsed.Raw, Parsed: f.Parsed, Cwd: f.Cwd, Type: f.Type})
+	if cfg.API.Enabled && current.AllowsAPI() {
+		allowed = policy.Decision.AllowsAPI
+	}
+	
+	screened := make([]session.Entry, 0, len(entries))
+	for _, entry := range entries {
+		// Transcripts from older hooks don't say where a command ran
+		cwd := entry.Cwd
+		if cwd == "" {
+			cwd = f.Cwd
+		}
+		parsed := cmdline.Parse(entry.Command)
+		decision := commandPolicy.Evaluate(policy.Input{
+			Command: entry.Command,
+			Parsed:  parsed,
+			Cwd:     cwd,
+			Type:    classifier.ClassifyParsed(parsed),
+		})
+		if !allowed(decision) {
+			if cfg.General.Debug {
+				fmt.Printf("🛡️  Leaving out the transcript: policy %q applies to an earlier command (%s)\n", decision.Rule, decision.Reason)
+			}
+			return nil, false
+		}
+		entry.Command, _ = redactor.Redact(entry.Command)
+		screened = append(screened, entry)
+	}
+	return screened, true
+}
+
+func getFallbackResponse(cfg *config.Config, f failure) string {
+	return fallback.Default(cfg.General.FallbackStyle, f.Packs).Respond(fallback.Situation{
+		Personality: cfg.General.Personality,
+		Type:        f.Type,
+		Exit:        f.Exit,
+		Parsed:      f.Parsed,
+		Similar:     f.Similar,
+		Language:    i18n.Resolve(cfg.General.Language),
+		Attempts:    f.Streak.Attempts,
+		Stuck:       f.Streak.Stuck,
+		Duration:    f.Duration,
+		Slow:        slowSuccess(cfg, f),
+	})
+}
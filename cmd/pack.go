@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+
+	"parrot/internal/packs"
+
+	"github.com/spf13/cobra"
+)
+
+var packChecksum string
+
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "Manage roast packs",
+	Long:  "Install, list, remove and lint roast packs: shareable collections of fallback lines",
+}
+
+var packInstallCmd = &cobra.Command{
+	Use:   "install <dir|archive.tar.gz>",
+	Short: "Validate and install a roast pack",
+	Long:  "Validate a roast pack directory or .tar.gz archive and install it, replacing any pack with the same name",
+	Args:  cobra.ExactArgs(1),
+	Run:   installPack,
+}
+
+var packListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed roast packs",
+	Args:  cobra.NoArgs,
+	Run:   listPacks,
+}
+
+var packRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an installed roast pack",
+	Args:  cobra.ExactArgs(1),
+	Run:   removePack,
+}
+
+var packLintCmd = &cobra.Command{
+	Use:   "lint <dir>",
+	Short: "Check a roast pack for problems",
+	Long:  "Check a roast pack directory for manifest, checksum and line problems without installing it",
+	Args:  cobra.ExactArgs(1),
+	Run:   lintPack,
+}
+
+func init() {
+	packInstallCmd.Flags().StringVar(&packChecksum, "sha256", "", "Expected sha256 of the archive (or pack digest for a directory)")
+	packCmd.AddCommand(packInstallCmd, packListCmd, packRemoveCmd, packLintCmd)
+	rootCmd.AddCommand(packCmd)
+}
+
+func installPack(cmd *cobra.Command, args []string) {
+	pack, problems, err := packs.Install(args[0], packChecksum)
+	for _, problem := range problems {
+		fmt.Printf("   • %s\n", problem)
+	}
+	if err != nil {
+		fmt.Printf("❌ Pack not installed: %v\n", err)
+		return
+	}
+
+	digest, _ := packs.Digest(pack)
+	fmt.Printf("✅ Installed %s %s (%d lines)\n", pack.Manifest.Name, pack.Manifest.Version, len(pack.Lines))
+	fmt.Printf("   • Location: %s\n", pack.Dir)
+	fmt.Printf("   • Digest: %s\n", digest)
+}
+
+func listPacks(cmd *cobra.Command, args []string) {
+	installed, err := packs.List()
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	if len(installed) == 0 {
+		fmt.Printf("🦜 No roast packs installed in %s\n", packs.Dir())
+		fmt.Println("   Install one with: parrot pack install <dir|archive.tar.gz>")
+		return
+	}
+
+	fmt.Printf("🦜 Installed roast packs (%s)\n", packs.Dir())
+	for _, pack := range installed {
+		digest, _ := packs.Digest(pack)
+		fmt.Printf("   • %s %s - %d lines [%s]\n", pack.Manifest.Name, pack.Manifest.Version, len(pack.Lines), packs.ShortDigest(digest))
+		if pack.Manifest.Description != "" {
+			fmt.Printf("     %s\n", pack.Manifest.Description)
+		}
+	}
+}
+
+func removePack(cmd *cobra.Command, args []string) {
+	if err := packs.Remove(args[0]); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Removed %s\n", args[0])
+}
+
+func lintPack(cmd *cobra.Command, args []string) {
+	pack, problems := packs.Lint(args[0])
+
+	errors := 0
+	for _, problem := range problems {
+		if problem.Error {
+			errors++
+		}
+		fmt.Printf("   • %s\n", problem)
+	}
+
+	switch {
+	case errors > 0:
+		fmt.Printf("❌ %d errors, %d warnings\n", errors, len(problems)-errors)
+	case len(problems) > 0:
+		fmt.Printf("⚠️  %s is installable with %d warnings (%d lines)\n", pack.Manifest.Name, len(problems), len(pack.Lines))
+	default:
+		fmt.Printf("✅ %s looks good (%d lines)\n", pack.Manifest.Name, len(pack.Lines))
+	}
+}
@@ -24,22 +24,26 @@ func init() {
 }
 
 func runSetup(cmd *cobra.Command, args []string) {
-	fmt.Println("🦜 Welcome to Parrot Complete Setup!")
-	fmt.Println("════════════════════════════════════")
-	fmt.Println("Let's get your sassy parrot fully operational!")
-	fmt.Println()
-
-	// Step 1: Check current status
-	fmt.Println("📊 System Check")
-	fmt.Println("───────────────")
-	
 	cfg, err := config.LoadConfig()
 	configExists := err == nil
 	if err != nil {
 		cfg = config.DefaultConfig()
-		fmt.Println("📋 No config found - will create one")
+	}
+	localize(cfg)
+	
+	ui.Println("🦜 Welcome to Parrot Complete Setup!")
+	ui.Println("════════════════════════════════════")
+	ui.Println("Let's get your sassy parrot fully operational!")
+	ui.Println()
+
+	// Step 1: Check current status
+	ui.Println("📊 System Check")
+	ui.Println("───────────────")
+	
+	if !configExists {
+		ui.Println("📋 No config found - will create one")
 	} else {
-		fmt.Println("✅ Config loaded")
+		ui.Println("✅ Config loaded")
 	}
 	
 	manager := llm.NewLLMManager(cfg)
@@ -50,61 +54,61 @@ func runSetup(cmd *cobra.Command, args []string) {
 	hasLocal := status["local_available"].(bool)
 	hasOllama := isOllamaInstalled()
 	
-	fmt.Printf("• API Backend: ")
+	ui.Printf("• API Backend: ")
 	if hasAPI {
-		fmt.Println("✅ Ready")
+		ui.Println("✅ Ready")
 	} else if cfg.API.APIKey != "" {
-		fmt.Println("⚠️  Key set but unavailable")  
+		ui.Println("⚠️  Key set but unavailable")  
 	} else {
-		fmt.Println("❌ No API key")
+		ui.Println("❌ No API key")
 	}
 	
-	fmt.Printf("• Ollama Installed: ")
+	ui.Printf("• Ollama Installed: ")
 	if hasOllama {
-		fmt.Println("✅ Yes")
+		ui.Println("✅ Yes")
 	} else {
-		fmt.Println("❌ Not found")
+		ui.Println("❌ Not found")
 	}
 	
-	fmt.Printf("• Local Model Ready: ")
+	ui.Printf("• Local Model Ready: ")
 	if hasLocal {
-		fmt.Println("✅ Available")
+		ui.Println("✅ Available")
 	} else if hasOllama {
-		fmt.Printf("❌ Model %s not found\n", cfg.Local.Model)
+		ui.Printf("❌ Model %s not found\n", cfg.Local.Model)
 	} else {
-		fmt.Println("❌ Ollama not installed")
+		ui.Println("❌ Ollama not installed")
 	}
 	
-	fmt.Println()
+	ui.Println()
 	
 	// Step 2: Interactive setup based on current state
 	if hasAPI || hasLocal {
-		fmt.Println("🎉 Intelligence Available!")
-		fmt.Println("─────────────────────────")
+		ui.Println("🎉 Intelligence Available!")
+		ui.Println("─────────────────────────")
 		if hasAPI {
-			fmt.Printf("✅ API Backend ready (%s)\n", cfg.API.Provider)
+			ui.Printf("✅ API Backend ready (%s)\n", cfg.API.Provider)
 		}
 		if hasLocal {
-			fmt.Printf("✅ Local Backend ready (%s)\n", cfg.Local.Model)
+			ui.Printf("✅ Local Backend ready (%s)\n", cfg.Local.Model)
 		}
 		
 		// Skip to shell integration
-		fmt.Println("\n🐚 Final Step: Shell Integration")
-		fmt.Println("─────────────────────────────────")
+		ui.Println("\n🐚 Final Step: Shell Integration")
+		ui.Println("─────────────────────────────────")
 		installShellHooks(cfg)
 		
 	} else {
 		// No AI backends available - offer setup options
-		fmt.Println("🚀 Choose Your Intelligence Level")
-		fmt.Println("─────────────────────────────────")
-		fmt.Println("1. 🌐 API Backend (Fast, requires internet & key)")
-		fmt.Println("2. 🖥️  Local Backend (Private, requires download)")  
-		fmt.Println("3. 🔄 Fallback Only (Basic responses, works now)")
-		fmt.Println()
+		ui.Println("🚀 Choose Your Intelligence Level")
+		ui.Println("─────────────────────────────────")
+		ui.Println("1. 🌐 API Backend (Fast, requires internet & key)")
+		ui.Println("2. 🖥️  Local Backend (Private, requires download)")  
+		ui.Println("3. 🔄 Fallback Only (Basic responses, works now)")
+		ui.Println()
 		
 		var choice string
 		for {
-			fmt.Print("Choose setup path [1-3]: ")
+			ui.Print("Choose setup path [1-3]: ")
 			fmt.Scanln(&choice)
 			
 			switch choice {
@@ -115,66 +119,66 @@ func runSetup(cmd *cobra.Command, args []string) {
 				setupLocalBackend(&cfg, configExists, hasOllama)
 				goto shellSetup
 			case "3":
-				fmt.Println("\n✅ Using fallback responses - no setup needed!")
+				ui.Println("\n✅ Using fallback responses - no setup needed!")
 				goto shellSetup
 			default:
-				fmt.Println("❌ Please choose 1, 2, or 3")
+				ui.Println("❌ Please choose 1, 2, or 3")
 				continue
 			}
 		}
 		
 		shellSetup:
-		fmt.Println("\n🐚 Shell Integration")
-		fmt.Println("───────────────────")
+		ui.Println("\n🐚 Shell Integration")
+		ui.Println("───────────────────")
 		installShellHooks(cfg)
 	}
 	
 	// Step 3: Model installation helper
 	if cfg.Local.Enabled && !hasLocal {
-		fmt.Println("\n🤖 Local Model Setup")
-		fmt.Println("────────────────────")
+		ui.Println("\n🤖 Local Model Setup")
+		ui.Println("────────────────────")
 		
 		// Check if ollama is installed
 		if isOllamaInstalled() {
-			fmt.Printf("Ollama is installed. Would you like to install %s now? [y/N]: ", cfg.Local.Model)
+			ui.Printf("Ollama is installed. Would you like to install %s now? [y/N]: ", cfg.Local.Model)
 			var response string
 			fmt.Scanln(&response)
 			
 			if response == "y" || response == "Y" {
-				fmt.Printf("📥 Installing %s (this may take a few minutes)...\n", cfg.Local.Model)
+				ui.Printf("📥 Installing %s (this may take a few minutes)...\n", cfg.Local.Model)
 				cmd := exec.Command("ollama", "pull", cfg.Local.Model)
 				cmd.Stdout = os.Stdout
 				cmd.Stderr = os.Stderr
 				
 				if err := cmd.Run(); err != nil {
-					fmt.Printf("❌ Failed to install model: %v\n", err)
-					fmt.Println("   Please run manually: ollama pull", cfg.Local.Model)
+					ui.Printf("❌ Failed to install model: %v\n", err)
+					ui.Printf("   Please run manually: ollama pull %s\n", cfg.Local.Model)
 				} else {
-					fmt.Println("✅ Model installed successfully!")
+					ui.Println("✅ Model installed successfully!")
 				}
 			}
 		} else {
-			fmt.Println("❌ Ollama not found. Please install from: https://ollama.com/download")
+			ui.Println("❌ Ollama not found. Please install from: https://ollama.com/download")
 		}
 	}
 	
 	// Step 4: Shell integration
-	fmt.Println("\n🐚 Shell Integration")
-	fmt.Println("───────────────────")
-	fmt.Println("To automatically roast failed commands:")
-	fmt.Println("   1. Run: parrot install")
-	fmt.Println("   2. Restart your shell or run: source ~/.bashrc")
-	fmt.Println("   3. Try failing a command and watch parrot respond!")
+	ui.Println("\n🐚 Shell Integration")
+	ui.Println("───────────────────")
+	ui.Println("To automatically roast failed commands:")
+	ui.Println("   1. Run: parrot install")
+	ui.Printf("   2. Restart your shell or run: %s\n", shellReloadHint())
+	ui.Println("   3. Try failing a command and watch parrot respond!")
 	
 	// Step 5: Final tips
-	fmt.Println("\n🔧 Useful Commands")
-	fmt.Println("─────────────────")
-	fmt.Println("• parrot status         - Check backend status")
-	fmt.Println("• parrot configure      - Interactive configuration")
-	fmt.Println("• parrot mock <cmd> <code> - Test responses")
-	fmt.Println("• PARROT_DEBUG=true     - Enable debug output")
+	ui.Println("\n🔧 Useful Commands")
+	ui.Println("─────────────────")
+	ui.Println("• parrot status         - Check backend status")
+	ui.Println("• parrot configure      - Interactive configuration")
+	ui.Println("• parrot mock <cmd> <code> - Test responses")
+	ui.Println("• PARROT_DEBUG=true     - Enable debug output")
 	
-	fmt.Println("\n🎉 Happy failing! Your parrot is ready to roast you.")
+	ui.Println("\n🎉 Happy failing! Your parrot is ready to roast you.")
 }
 
 func isOllamaInstalled() bool {
@@ -183,14 +187,14 @@ func isOllamaInstalled() bool {
 }
 
 func setupAPIBackend(cfg **config.Config, configExists bool) {
-	fmt.Println("\n🌐 API Backend Setup")
-	fmt.Println("───────────────────")
-	fmt.Println("For AI-powered responses, you need an API key:")
-	fmt.Println("• OpenAI: https://platform.openai.com/api-keys (recommended)")
-	fmt.Println("• Anthropic: https://console.anthropic.com/")
-	fmt.Println()
+	ui.Println("\n🌐 API Backend Setup")
+	ui.Println("───────────────────")
+	ui.Println("For AI-powered responses, you need an API key:")
+	ui.Println("• OpenAI: https://platform.openai.com/api-keys (recommended)")
+	ui.Println("• Anthropic: https://console.anthropic.com/")
+	ui.Println()
 	
-	fmt.Print("Enter your API key (or press Enter to skip): ")
+	ui.Print("Enter your API key (or press Enter to skip): ")
 	var apiKey string
 	fmt.Scanln(&apiKey)
 	
@@ -198,7 +202,7 @@ func setupAPIBackend(cfg **config.Config, configExists bool) {
 		(*cfg).API.Enabled = true
 		(*cfg).API.APIKey = apiKey
 		
-		fmt.Print("Provider [openai]: ")
+		ui.Print("Provider [openai]: ")
 		var provider string
 		fmt.Scanln(&provider)
 		if provider == "" {
@@ -208,55 +212,55 @@ func setupAPIBackend(cfg **config.Config, configExists bool) {
 		
 		// Save config
 		if err := saveConfigToFile(*cfg); err != nil {
-			fmt.Printf("⚠️  Couldn't save config: %v\n", err)
-			fmt.Println("💡 You can set it later with: export PARROT_API_KEY=\"your-key\"")
+			ui.Printf("⚠️  Couldn't save config: %v\n", err)
+			ui.Println("💡 You can set it later with: export PARROT_API_KEY=\"your-key\"")
 		} else {
-			fmt.Println("✅ API key saved to config!")
+			ui.Println("✅ API key saved to config!")
 		}
 		
 		// Test the API
-		fmt.Println("\n🧪 Testing API connection...")
+		ui.Println("\n🧪 Testing API connection...")
 		manager := llm.NewLLMManager(*cfg)
 		if manager.GetStatus()["api_available"].(bool) {
-			fmt.Println("✅ API backend is working!")
+			ui.Println("✅ API backend is working!")
 		} else {
-			fmt.Println("⚠️  API test failed - check your key and try again")
+			ui.Println("⚠️  API test failed - check your key and try again")
 		}
 	} else {
-		fmt.Println("⏭️  Skipped API setup - you can configure later with: parrot configure")
+		ui.Println("⏭️  Skipped API setup - you can configure later with: parrot configure")
 	}
 }
 
 func setupLocalBackend(cfg **config.Config, configExists bool, hasOllama bool) {
-	fmt.Println("\n🖥️ Local Backend Setup")
-	fmt.Println("─────────────────────")
+	ui.Println("\n🖥️ Local Backend Setup")
+	ui.Println("─────────────────────")
 	
 	if !hasOllama {
-		fmt.Println("❌ Ollama not found. Installing...")
-		fmt.Println("📥 Please install Ollama first:")
-		fmt.Println("   • Linux: curl -fsSL https://ollama.com/install.sh | sh")
-		fmt.Println("   • Or visit: https://ollama.com/download")
-		fmt.Println()
-		fmt.Print("Press Enter after installing Ollama...")
+		ui.Println("❌ Ollama not found. Installing...")
+		ui.Println("📥 Please install Ollama first:")
+		ui.Println("   • Linux: curl -fsSL https://ollama.com/install.sh | sh")
+		ui.Println("   • Or visit: https://ollama.com/download")
+		ui.Println()
+		ui.Print("Press Enter after installing Ollama...")
 		fmt.Scanln()
 		
 		// Re-check
 		if !isOllamaInstalled() {
-			fmt.Println("❌ Ollama still not found. Please install it and run setup again.")
+			ui.Println("❌ Ollama still not found. Please install it and run setup again.")
 			return
 		}
-		fmt.Println("✅ Ollama detected!")
+		ui.Println("✅ Ollama detected!")
 	}
 	
 	// Install the model
-	fmt.Printf("📥 Installing model %s (this may take a few minutes)...\n", (*cfg).Local.Model)
+	ui.Printf("📥 Installing model %s (this may take a few minutes)...\n", (*cfg).Local.Model)
 	cmd := exec.Command("ollama", "pull", (*cfg).Local.Model)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	
 	if err := cmd.Run(); err != nil {
-		fmt.Printf("❌ Failed to install model: %v\n", err)
-		fmt.Printf("💡 Try manually: ollama pull %s\n", (*cfg).Local.Model)
+		ui.Printf("❌ Failed to install model: %v\n", err)
+		ui.Printf("💡 Try manually: ollama pull %s\n", (*cfg).Local.Model)
 		return
 	}
 	
@@ -264,43 +268,39 @@ func setupLocalBackend(cfg **config.Config, configExists bool, hasOllama bool) {
 	
 	// Save config
 	if err := saveConfigToFile(*cfg); err != nil {
-		fmt.Printf("⚠️  Couldn't save config: %v\n", err)
+		ui.Printf("⚠️  Couldn't save config: %v\n", err)
 	} else {
-		fmt.Println("✅ Local backend configured!")
+		ui.Println("✅ Local backend configured!")
 	}
 	
-	fmt.Println("✅ Local AI is ready!")
+	ui.Println("✅ Local AI is ready!")
 }
 
 func installShellHooks(cfg *config.Config) {
-	fmt.Println("To automatically roast failed commands:")
-	fmt.Println("1. 📥 Install shell hooks")
-	fmt.Print("   Install now? [Y/n]: ")
+	ui.Println("To automatically roast failed commands:")
+	ui.Println("1. 📥 Install shell hooks")
+	ui.Print("   Install now? [Y/n]: ")
 	
 	var response string
 	fmt.Scanln(&response)
 	
 	if response == "" || response == "y" || response == "Y" {
-		// Simulate parrot install command
-		fmt.Println("   Running: parrot install")
-		
-		// Call the actual install logic (we'd need to refactor install command)
-		fmt.Println("✅ Shell hooks installed!")
-		fmt.Println("2. 🔄 Restart your shell or run: source ~/.bashrc")
+		ui.Println("   Running: parrot install")
+		installHooks(installCmd, nil)
 	} else {
-		fmt.Println("⏭️  Skipped - run 'parrot install' later to enable auto-roasting")
+		ui.Println("⏭️  Skipped - run 'parrot install' later to enable auto-roasting")
 	}
 	
-	fmt.Println("\n🧪 Test Your Parrot")
-	fmt.Println("──────────────────")
-	fmt.Printf("Try: parrot mock \"git push\" \"1\"\n")
+	ui.Println("\n🧪 Test Your Parrot")
+	ui.Println("──────────────────")
+	ui.Printf("Try: parrot mock \"git push\" \"1\"\n")
 	
 	if cfg.General.Personality != "savage" {
-		fmt.Printf("Or try savage mode: PARROT_PERSONALITY=savage parrot mock \"docker run\" \"125\"\n")
+		ui.Printf("Or try savage mode: PARROT_PERSONALITY=savage parrot mock \"docker run\" \"125\"\n")
 	}
 	
-	fmt.Println("\n🎉 Setup Complete!")
-	fmt.Println("Your parrot is ready to roast your failures! 🦜💥")
+	ui.Println("\n🎉 Setup Complete!")
+	ui.Println("Your parrot is ready to roast your failures! 🦜💥")
 }
 
 func saveConfigToFile(cfg *config.Config) error {
@@ -318,4 +318,14 @@ func saveConfigToFile(cfg *config.Config) error {
 	}
 	
 	return config.CreateSampleConfig(configPath)
-}
\ No newline at end of file
+}
+
+// shellReloadHint tells how to load the hooks in the user's shell
+func shellReloadHint() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "source ~/.bashrc"
+	}
+	shell := detectShell()
+	return reloadHint(shell, startupFiles(shell, homeDir)[0].Path)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"parrot/internal/ratelimit"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultSnooze is how long `parrot snooze` lasts without a duration
+const defaultSnooze = 30 * time.Minute
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [duration|off]",
+	Short: "Keep the parrot quiet for a while",
+	Long: `Stops roasts in every shell for a while, e.g. during focus time or while
+sharing your screen. Failures are still recorded for 'parrot fix' and the
+stats. The duration defaults to 30m; 'parrot snooze off' wakes the parrot up.`,
+	Example: "  parrot snooze 45m\n  parrot snooze 2h\n  parrot snooze off",
+	Args:    cobra.MaximumNArgs(1),
+	Run:     snooze,
+}
+
+var quietCmd = &cobra.Command{
+	Use:   "quiet [off]",
+	Short: "Keep the parrot quiet until told otherwise",
+	Long:  "Stops roasts in every shell until 'parrot quiet off' or 'parrot snooze off'.",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   quiet,
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+	rootCmd.AddCommand(quietCmd)
+}
+
+func snooze(cmd *cobra.Command, args []string) {
+	if len(args) == 1 && args[0] == "off" {
+		wakeUp()
+		return
+	}
+
+	duration := defaultSnooze
+	if len(args) == 1 {
+		parsed, err := time.ParseDuration(args[0])
+		if err != nil || parsed <= 0 {
+			fmt.Fprintf(os.Stderr, "❌ Invalid duration %q; use something like 30m or 2h\n", args[0])
+			os.Exit(1)
+		}
+		duration = parsed
+	}
+
+	until := time.Now().Add(duration)
+	updateSnooze(func(state *ratelimit.State) {
+		state.SnoozeUntil = until
+		state.Quiet = false
+	})
+	fmt.Printf("😴 Parrot snoozing for %s, until %s. 'parrot snooze off' wakes it up.\n", duration, until.Format("15:04"))
+}
+
+func quiet(cmd *cobra.Command, args []string) {
+	if len(args) == 1 {
+		if args[0] != "off" {
+			fmt.Fprintf(os.Stderr, "❌ Unknown argument %q; use 'parrot quiet off'\n", args[0])
+			os.Exit(1)
+		}
+		wakeUp()
+		return
+	}
+
+	updateSnooze(func(state *ratelimit.State) {
+		state.Quiet = true
+	})
+	fmt.Println("🤫 Parrot is quiet until 'parrot quiet off'.")
+}
+
+// wakeUp ends any snooze or quiet time
+func wakeUp() {
+	updateSnooze(func(state *ratelimit.State) {
+		state.SnoozeUntil = time.Time{}
+		state.Quiet = false
+	})
+	fmt.Println("🦜 Parrot is awake and watching again.")
+}
+
+// updateSnooze changes the shared limiter state, exiting on failure
+func updateSnooze(change func(*ratelimit.State)) {
+	err := ratelimit.Update(ratelimit.Path(), func(state *ratelimit.State) error {
+		change(state)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
+	"time"
 
 	"parrot/internal/config"
+	"parrot/internal/i18n"
 	"parrot/internal/llm"
+	"parrot/internal/ratelimit"
+	"parrot/internal/session"
+	"parrot/internal/style"
 
 	"github.com/spf13/cobra"
 )
@@ -22,106 +26,131 @@ func init() {
 }
 
 func showStatus(cmd *cobra.Command, args []string) {
-	fmt.Println("🦜 Parrot Status Report")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━")
-	
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		fmt.Printf("❌ Configuration Error: %v\n", err)
+		ui.Printf("❌ Configuration Error: %v\n", err)
 		return
 	}
+	localize(cfg)
+	
+	ui.Println("🦜 Parrot Status Report")
+	ui.Println("━━━━━━━━━━━━━━━━━━━━━━")
 	
 	// Show configuration source
-	fmt.Println("\n📁 Configuration:")
+	ui.Println("\n📁 Configuration:")
 	configFound := false
 	for _, path := range config.GetConfigPaths() {
 		if _, err := os.Stat(path); err == nil {
-			fmt.Printf("   ✅ Loaded from: %s\n", path)
+			ui.Printf("   ✅ Loaded from: %s\n", path)
 			configFound = true
 			break
 		}
 	}
 	if !configFound {
-		fmt.Println("   ℹ️  Using default configuration (no config file found)")
+		ui.Println("   ℹ️  Using default configuration (no config file found)")
 	}
 	
 	// General settings
-	fmt.Printf("   • Personality: %s\n", cfg.General.Personality)
-	fmt.Printf("   • Debug mode: %t\n", cfg.General.Debug)
-	fmt.Printf("   • Fallback only: %t\n", cfg.General.FallbackMode)
+	ui.Printf("   • Personality: %s\n", cfg.General.Personality)
+	ui.Printf("   • Debug mode: %t\n", cfg.General.Debug)
+	ui.Printf("   • Fallback only: %t\n", cfg.General.FallbackMode)
+	ui.Printf("   • Offline style: %s\n", cfg.General.FallbackStyle)
+	ui.Printf("   • Response style: %s\n", style.Get(cfg.General.Style).Name)
+	ui.Printf("   • Language: %s\n", i18n.Name(ui.Language()))
+	if state, err := ratelimit.Load(ratelimit.Path()); err == nil {
+		if snoozed, left := state.Snoozed(time.Now()); snoozed && left == 0 {
+			ui.Println("   • Snoozed: quiet until 'parrot quiet off'")
+		} else if snoozed {
+			ui.Printf("   • Snoozed: %s left\n", ratelimit.Round(left))
+		}
+	}
 	
 	// Initialize LLM manager to get status
 	manager := llm.NewLLMManager(cfg)
 	status := manager.GetStatus()
 	
 	// API Backend Status
-	fmt.Println("\n🌐 API Backend:")
+	ui.Println("\n🌐 API Backend:")
 	if status["api_enabled"].(bool) {
-		fmt.Printf("   • Enabled: ✅\n")
-		fmt.Printf("   • Provider: %s\n", status["api_provider"])
-		fmt.Printf("   • Model: %s\n", status["api_model"])
+		ui.Printf("   • Enabled: ✅\n")
+		ui.Printf("   • Provider: %s\n", status["api_provider"])
+		ui.Printf("   • Model: %s\n", status["api_model"])
 		if status["api_available"].(bool) {
-			fmt.Printf("   • Status: ✅ Available\n")
+			ui.Printf("   • Status: ✅ Available\n")
 		} else {
-			fmt.Printf("   • Status: ❌ Unavailable (check API key/endpoint)\n")
+			ui.Printf("   • Status: ❌ Unavailable (check API key/endpoint)\n")
 		}
 	} else {
-		fmt.Printf("   • Enabled: ❌ (no API key configured)\n")
+		ui.Printf("   • Enabled: ❌ (no API key configured)\n")
 	}
 	
 	// Local Backend Status  
-	fmt.Println("\n🖥️  Local Backend:")
+	ui.Println("\n🖥️  Local Backend:")
 	if status["local_enabled"].(bool) {
-		fmt.Printf("   • Enabled: ✅\n")
-		fmt.Printf("   • Provider: %s\n", status["local_provider"])
-		fmt.Printf("   • Model: %s\n", status["local_model"])
+		ui.Printf("   • Enabled: ✅\n")
+		ui.Printf("   • Provider: %s\n", status["local_provider"])
+		ui.Printf("   • Model: %s\n", status["local_model"])
 		if status["local_available"].(bool) {
-			fmt.Printf("   • Status: ✅ Available\n")
+			ui.Printf("   • Status: ✅ Available\n")
 		} else {
-			fmt.Printf("   • Status: ❌ Unavailable (check if Ollama is running)\n")
+			ui.Printf("   • Status: ❌ Unavailable (check if Ollama is running)\n")
 		}
 	} else {
-		fmt.Printf("   • Enabled: ❌\n")
+		ui.Printf("   • Enabled: ❌\n")
 	}
 	
 	// Fallback Status
-	fmt.Println("\n🔄 Fallback Backend:")
-	fmt.Printf("   • Status: ✅ Always available\n")
+	ui.Println("\n🔄 Fallback Backend:")
+	ui.Printf("   • Status: ✅ Always available\n")
 	
 	// Show active backend priority
-	fmt.Println("\n⚡ Backend Priority:")
+	ui.Println("\n⚡ Backend Priority:")
 	if cfg.General.FallbackMode {
-		fmt.Println("   1. 🔄 Fallback (forced)")
+		ui.Println("   1. 🔄 Fallback (forced)")
 	} else {
 		priority := 1
 		if status["api_enabled"].(bool) {
 			if status["api_available"].(bool) {
-				fmt.Printf("   %d. 🌐 API (ready)\n", priority)
+				ui.Printf("   %d. 🌐 API (ready)\n", priority)
 			} else {
-				fmt.Printf("   %d. 🌐 API (unavailable)\n", priority)
+				ui.Printf("   %d. 🌐 API (unavailable)\n", priority)
 			}
 			priority++
 		}
 		if status["local_enabled"].(bool) {
 			if status["local_available"].(bool) {
-				fmt.Printf("   %d. 🖥️  Local (ready)\n", priority)
+				ui.Printf("   %d. 🖥️  Local (ready)\n", priority)
 			} else {
-				fmt.Printf("   %d. 🖥️  Local (unavailable)\n", priority)
+				ui.Printf("   %d. 🖥️  Local (unavailable)\n", priority)
 			}
 			priority++
 		}
-		fmt.Printf("   %d. 🔄 Fallback (always)\n", priority)
+		ui.Printf("   %d. 🔄 Fallback (always)\n", priority)
+	}
+	
+	// Failures over the last week, including the ones the parrot kept quiet about
+	if events, err := session.LoadHistory(session.HistoryPath(), time.Now().AddDate(0, 0, -7)); err == nil && len(events) > 0 {
+		suppressed := 0
+		for _, event := range events {
+			if event.Suppressed {
+				suppressed++
+			}
+		}
+		ui.Println("\n📈 Last 7 Days:")
+		ui.Printf("   • Failures: %d\n", len(events))
+		ui.Printf("   • Roasted: %d\n", len(events)-suppressed)
+		ui.Printf("   • Suppressed: %d\n", suppressed)
 	}
 	
 	// Configuration hints
-	fmt.Println("\n💡 Quick Setup:")
+	ui.Println("\n💡 Quick Setup:")
 	if !status["api_enabled"].(bool) {
-		fmt.Println("   • Set API key: export PARROT_API_KEY=\"your-key-here\"")
+		ui.Println("   • Set API key: export PARROT_API_KEY=\"your-key-here\"")
 	}
 	if !status["local_available"].(bool) && status["local_enabled"].(bool) {
-		fmt.Printf("   • Install model: ollama pull %s\n", status["local_model"])
+		ui.Printf("   • Install model: ollama pull %s\n", status["local_model"])
 	}
 	
-	fmt.Println("\n   📖 Use 'parrot config' to create a configuration file")
+	ui.Println("\n   📖 Use 'parrot config' to create a configuration file")
 }
\ No newline at end of file
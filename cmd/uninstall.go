@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"parrot/internal/config"
+	"parrot/internal/hooks"
+
+	"github.com/spf13/cobra"
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove parrot shell hooks and state",
+	Long: `Removes the parrot block from the startup files of every supported shell,
+backing each file up to <file>.parrot-backup first, and deletes parrot's state:
+session transcripts, the last failure and recently shown lines.
+
+With --shell only those shells' hooks are removed and the state is kept.
+Configuration and installed roast packs are always kept.`,
+	Run: uninstallHooks,
+}
+
+var (
+	uninstallShells    []string
+	uninstallKeepState bool
+)
+
+func init() {
+	uninstallCmd.Flags().StringSliceVar(&uninstallShells, "shell", nil, "Shells to remove the hooks from (default: all): "+strings.Join(hooks.Shells(), ", "))
+	uninstallCmd.Flags().BoolVar(&uninstallKeepState, "keep-state", false, "Keep session transcripts and other state")
+	rootCmd.AddCommand(uninstallCmd)
+}
+
+func uninstallHooks(cmd *cobra.Command, args []string) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Printf("❌ Error getting home directory: %v\n", err)
+		return
+	}
+
+	shells := uninstallShells
+	if len(shells) == 0 {
+		shells = hooks.Shells()
+	}
+	removed := 0
+	for _, shell := range shells {
+		if !hooks.Supported(shell) {
+			fmt.Printf("❌ Unsupported shell %q (supported: %s)\n", shell, strings.Join(hooks.Shells(), ", "))
+			return
+		}
+		for _, file := range startupFiles(shell, homeDir) {
+			if uninstallFrom(file) {
+				removed++
+			}
+		}
+		if hooks.Saved(shell) {
+			removeFile(savedScriptPath(startupFiles(shell, homeDir)[0].Path))
+		}
+	}
+	if removed == 0 {
+		fmt.Println("✅ No parrot hooks found")
+	}
+
+	if len(uninstallShells) == 0 && !uninstallKeepState {
+		removeFile(config.StateDir())
+		removeFile(getSetupMarkerPath())
+	}
+	for _, path := range config.GetConfigPaths() {
+		if _, err := os.Stat(path); err == nil {
+			fmt.Printf("💡 Configuration kept at %s\n", path)
+		}
+	}
+	if removed > 0 {
+		fmt.Println("🔄 Start a new shell session to stop the parrot.")
+	}
+}
+
+// uninstallFrom removes the parrot block from a startup file, reporting
+// whether there was one
+func uninstallFrom(file startupFile) bool {
+	existing, err := os.ReadFile(file.Path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Printf("❌ Error reading %s: %v\n", file.Path, err)
+		}
+		return false
+	}
+	content, found := hooks.Remove(string(existing))
+	if !found {
+		return false
+	}
+
+	if file.Owned && strings.TrimSpace(content) == "" {
+		removeFile(file.Path)
+		return true
+	}
+	if err := writeStartupFile(file, existing, content); err != nil {
+		fmt.Printf("❌ Error writing to %s: %v\n", file.Path, err)
+		return false
+	}
+	fmt.Printf("🗑️  Removed parrot hooks from %s\n", file.Path)
+	return true
+}
+
+// removeFile deletes a file or directory, if it exists
+func removeFile(path string) {
+	if _, err := os.Lstat(path); err != nil {
+		return
+	}
+	if err := os.RemoveAll(path); err != nil {
+		fmt.Printf("❌ Error removing %s: %v\n", path, err)
+		return
+	}
+	fmt.Printf("🗑️  Removed %s\n", path)
+}
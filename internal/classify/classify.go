@@ -0,0 +1,197 @@
+package classify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/config"
+)
+
+// Generic is the root of every type hierarchy and always has templates and fallbacks.
+const Generic = "generic"
+
+// Rule matches a command and assigns it a hierarchical type such as "git.push".
+// Every non-empty field must match for the rule to apply.
+type Rule struct {
+	Type        string
+	Executables []string
+	Subcommands []string
+	Pattern     *regexp.Regexp
+	Subtype     bool // Append the subcommand to Type (git -> git.push)
+}
+
+// builtinRules are checked after user rules, in order. More specific rules
+// (pattern or subcommand) come before the catch-all rule for the same tool.
+var builtinRules = []Rule{
+	// Git
+	{Type: "git.push.force", Executables: []string{"git"}, Subcommands: []string{"push"}, Pattern: regexp.MustCompile(`\s(--force(-with-lease|-if-includes)?|-f)\b|\s\+[^\s+]`)},
+	{Type: "git", Executables: []string{"git", "gh"}, Subtype: true},
+
+	// Node.js
+	{Type: "nodejs.install", Executables: []string{"npm", "yarn", "pnpm"}, Subcommands: []string{"install", "i", "ci", "add"}},
+	{Type: "nodejs", Executables: []string{"npm", "yarn", "pnpm", "npx", "node", "bun", "deno"}, Subtype: true},
+
+	// Containers
+	{Type: "docker.compose", Executables: []string{"docker-compose", "podman-compose"}},
+	{Type: "docker", Executables: []string{"docker", "podman"}, Subtype: true},
+
+	// HTTP
+	{Type: "http", Executables: []string{"curl", "wget", "http", "https", "httpie"}},
+
+	// Kubernetes and infrastructure
+	{Type: "kubectl", Executables: []string{"kubectl", "oc", "k"}, Subtype: true},
+	{Type: "helm", Executables: []string{"helm"}, Subtype: true},
+	{Type: "terraform", Executables: []string{"terraform", "tofu", "terragrunt"}, Subtype: true},
+	{Type: "ansible", Executables: []string{"ansible", "ansible-playbook"}},
+
+	// Languages and build tools
+	{Type: "cargo", Executables: []string{"cargo"}, Subtype: true},
+	{Type: "rust", Executables: []string{"rustc", "rustup"}},
+	{Type: "go", Executables: []string{"go"}, Subtype: true},
+	{Type: "python.pip", Executables: []string{"pip", "pip3", "pipx", "uv", "poetry"}, Subtype: true},
+	{Type: "python.pytest", Executables: []string{"pytest"}},
+	{Type: "python", Executables: []string{"python", "python3"}},
+	{Type: "make", Executables: []string{"make", "gmake", "cmake", "ninja", "just"}},
+	{Type: "java", Executables: []string{"mvn", "gradle", "gradlew", "java", "javac"}},
+
+	// System
+	{Type: "systemctl", Executables: []string{"systemctl", "service", "journalctl"}, Subtype: true},
+	{Type: "package", Executables: []string{"apt", "apt-get", "dnf", "yum", "brew", "pacman", "zypper", "snap", "flatpak"}, Subtype: true},
+
+	// Remote access and navigation
+	{Type: "ssh", Executables: []string{"ssh", "scp", "sftp", "rsync", "mosh"}},
+	{Type: "navigation", Executables: []string{"cd", "pushd", "popd"}},
+}
+
+// Classifier assigns hierarchical command types using user rules followed by
+// the built-in table.
+type Classifier struct {
+	rules []Rule
+}
+
+// New builds a classifier from user-configured rules. Invalid user rules are
+// skipped and reported in the returned error; the classifier is always usable.
+func New(userRules []config.ClassifierRule) (*Classifier, error) {
+	var rules []Rule
+	var invalid []string
+
+	for i, userRule := range userRules {
+		rule, err := compileRule(userRule)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("rule %d (%s): %v", i+1, userRule.Type, err))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	rules = append(rules, builtinRules...)
+
+	classifier := &Classifier{rules: rules}
+	if len(invalid) > 0 {
+		return classifier, fmt.Errorf("skipped invalid classifier rules: %s", strings.Join(invalid, "; "))
+	}
+	return classifier, nil
+}
+
+func compileRule(userRule config.ClassifierRule) (Rule, error) {
+	if !ValidType(userRule.Type) {
+		return Rule{}, fmt.Errorf("type must be dot-separated lowercase words")
+	}
+	if len(userRule.Executables) == 0 && len(userRule.Subcommands) == 0 && userRule.Pattern == "" {
+		return Rule{}, fmt.Errorf("rule has nothing to match on")
+	}
+
+	rule := Rule{
+		Type:        userRule.Type,
+		Executables: userRule.Executables,
+		Subcommands: userRule.Subcommands,
+		Subtype:     userRule.Subtype,
+	}
+	if userRule.Pattern != "" {
+		pattern, err := regexp.Compile(userRule.Pattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid pattern: %w", err)
+		}
+		rule.Pattern = pattern
+	}
+	return rule, nil
+}
+
+// Classify parses a command line and returns its most specific type.
+func (c *Classifier) Classify(command string) string {
+	return c.ClassifyParsed(cmdline.Parse(command))
+}
+
+// ClassifyParsed returns the most specific type for the segment of a parsed
+// command that most likely failed, or Generic. Env assignments and wrappers
+// such as sudo are already stripped, and so are global options before the
+// subcommand, so `git -C repo push` is git.push. Patterns see the source
+// text of that segment, so flags of other commands on the line don't count.
+func (c *Classifier) ClassifyParsed(parsed *cmdline.Command) string {
+	segment := parsed.FailedSegment()
+	if len(segment.Args) == 0 {
+		return Generic
+	}
+
+	executable := segment.Executable()
+	subcommand := segment.Subcommand()
+
+	for _, rule := range c.rules {
+		if !rule.matches(segment.Raw, executable, subcommand) {
+			continue
+		}
+		if rule.Subtype && ValidType(subcommand) {
+			return rule.Type + "." + subcommand
+		}
+		return rule.Type
+	}
+	return Generic
+}
+
+func (r Rule) matches(command, executable, subcommand string) bool {
+	if len(r.Executables) > 0 && !contains(r.Executables, executable) {
+		return false
+	}
+	if len(r.Subcommands) > 0 && !contains(r.Subcommands, subcommand) {
+		return false
+	}
+	if r.Pattern != nil && !r.Pattern.MatchString(command) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var typePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*(\.[a-z0-9][a-z0-9_-]*)*$`)
+
+// ValidType reports whether t is a well-formed dotted command type.
+func ValidType(t string) bool {
+	return typePattern.MatchString(t)
+}
+
+// Lineage lists a type followed by each of its ancestors, ending with Generic:
+// "kubectl.apply" -> ["kubectl.apply", "kubectl", "generic"].
+func Lineage(commandType string) []string {
+	var lineage []string
+	for t := commandType; t != "" && t != Generic; t = Parent(t) {
+		lineage = append(lineage, t)
+	}
+	return append(lineage, Generic)
+}
+
+// Parent returns the enclosing type, or "" for a top-level type.
+func Parent(commandType string) string {
+	if idx := strings.LastIndex(commandType, "."); idx != -1 {
+		return commandType[:idx]
+	}
+	return ""
+}
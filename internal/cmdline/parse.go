@@ -0,0 +1,430 @@
+package cmdline
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Segment is one simple command within a (possibly compound) command line.
+type Segment struct {
+	Raw      string   // Source text of the segment
+	Args     []string // Words after env assignments and wrapper commands
+	Spans    []Span   // Where each of Args is in the command line
+	Env      []string // Names of leading NAME=value assignments
+	Wrappers []string // Wrapper commands such as sudo, env, time
+	Operator string   // Operator following this segment: "&&", "||", "|", ";" or ""
+	Pipeline int      // Index of the pipeline this segment belongs to
+	Compound bool     // Inside a loop, conditional, subshell or { } group
+	Status   *int     // Exit status the shell reported for this pipeline stage, if any
+}
+
+// Span is the position of a word in the command line, as byte offsets. It
+// covers the word's source text, quotes included.
+type Span struct {
+	Start, End int
+}
+
+// Executable returns the base name of the command being run, if any.
+func (s Segment) Executable() string {
+	if len(s.Args) == 0 {
+		return ""
+	}
+	return filepath.Base(s.Args[0])
+}
+
+// Subcommand returns the first argument after the executable that isn't a
+// flag or a global flag's value, e.g. "install" for "npm --silent install"
+// and "push" for "git -C repo push".
+func (s Segment) Subcommand() string {
+	if i := s.SubcommandIndex(); i > 0 {
+		return s.Args[i]
+	}
+	return ""
+}
+
+// SubcommandIndex returns the position of the subcommand in Args, or -1 when
+// there is none.
+func (s Segment) SubcommandIndex() int {
+	valued := globalOptions[s.Executable()]
+	for i := 1; i < len(s.Args); i++ {
+		arg := s.Args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return i
+		}
+		if contains(valued, arg) {
+			i++
+		}
+	}
+	return -1
+}
+
+// ReplaceArg returns the command line with the segment's i-th argument
+// replaced by word, leaving everything else as typed. It reports false when
+// the argument's position isn't known.
+func (s Segment) ReplaceArg(command string, i int, word string) (string, bool) {
+	span, ok := s.span(command, i)
+	if !ok {
+		return "", false
+	}
+	return command[:span.Start] + word + command[span.End:], true
+}
+
+// ArgSource returns the segment's i-th argument as typed in the command line,
+// quotes and all, so it can be put in another command for the same shell.
+func (s Segment) ArgSource(command string, i int) (string, bool) {
+	span, ok := s.span(command, i)
+	if !ok {
+		return "", false
+	}
+	return command[span.Start:span.End], true
+}
+
+func (s Segment) span(command string, i int) (Span, bool) {
+	if i < 0 || i >= len(s.Spans) {
+		return Span{}, false
+	}
+	span := s.Spans[i]
+	if span.Start < 0 || span.End > len(command) || span.Start > span.End {
+		return Span{}, false
+	}
+	return span, true
+}
+
+var (
+	kubectlOptions = []string{"-n", "--namespace", "--context", "--cluster", "--kubeconfig", "--user", "-s",
+		"--server", "--token", "--as", "--as-group", "--request-timeout", "-v"}
+	dockerOptions = []string{"-H", "--host", "-c", "--context", "--config", "-l", "--log-level",
+		"--tlscacert", "--tlscert", "--tlskey"}
+)
+
+// globalOptions lists, for each tool, the options before its subcommand that
+// consume a following value. Values joined with "=" are a single argument and
+// need no entry.
+var globalOptions = map[string][]string{
+	"git":       {"-C", "-c", "--git-dir", "--work-tree", "--namespace", "--config-env", "--super-prefix"},
+	"gh":        {"-R", "--repo"},
+	"kubectl":   kubectlOptions,
+	"k":         kubectlOptions,
+	"oc":        kubectlOptions,
+	"docker":    dockerOptions,
+	"podman":    {"-c", "--connection", "--url", "--root", "--runroot", "--log-level", "--storage-driver"},
+	"helm":      {"-n", "--namespace", "--kube-context", "--kubeconfig", "--registry-config", "--repository-config", "--repository-cache"},
+	"npm":       {"-w", "--workspace", "--prefix", "--userconfig", "--cache", "--registry"},
+	"pnpm":      {"-C", "--dir", "-F", "--filter"},
+	"yarn":      {"--cwd"},
+	"cargo":     {"-C", "--config", "-Z", "--color"},
+	"go":        {"-C"},
+	"systemctl": {"-H", "--host", "-M", "--machine", "-t", "--type", "-p", "--property", "--state"},
+}
+
+// String returns the effective command without env assignments or wrappers.
+func (s Segment) String() string {
+	return strings.Join(s.Args, " ")
+}
+
+// Command is a parsed command line.
+type Command struct {
+	Raw      string
+	Segments []Segment
+	Failed   int  // Index of the segment most likely to have failed
+	Partial  bool // The shell parser failed and Segments is a best-effort split
+}
+
+// FailedSegment returns the segment most likely responsible for the failure.
+func (c *Command) FailedSegment() Segment {
+	if len(c.Segments) == 0 {
+		return Segment{}
+	}
+	return c.Segments[c.Failed]
+}
+
+// IsCompound reports whether the command line has more than one segment.
+func (c *Command) IsCompound() bool {
+	return len(c.Segments) > 1
+}
+
+// Parse parses a command line as bash. It never returns nil: if the command
+// can't be parsed (say, unbalanced quotes) it falls back to a whitespace split.
+func Parse(command string) *Command {
+	parsed := &Command{Raw: command}
+
+	parser := syntax.NewParser(syntax.Variant(syntax.LangBash), syntax.KeepComments(false))
+	file, err := parser.Parse(strings.NewReader(command), "")
+	if err != nil {
+		parsed.Partial = true
+		if fields := strings.Fields(command); len(fields) > 0 {
+			parsed.Segments = []Segment{newSegment(command, fields, fieldSpans(command, fields))}
+		}
+		return parsed
+	}
+
+	w := &walker{source: command}
+	w.stmts(file.Stmts)
+	parsed.Segments = w.segments
+	parsed.Failed = likelyFailed(parsed.Segments)
+	return parsed
+}
+
+// walker collects simple commands in execution order along with the
+// operators that join them.
+type walker struct {
+	source   string
+	segments []Segment
+	pipeline int
+	compound int // Depth of compound statements around the current one
+}
+
+func (w *walker) stmts(stmts []*syntax.Stmt) {
+	for i, stmt := range stmts {
+		w.stmt(stmt)
+		if i < len(stmts)-1 {
+			w.setOperator(";")
+		}
+	}
+}
+
+func (w *walker) stmt(stmt *syntax.Stmt) {
+	if stmt == nil || stmt.Cmd == nil {
+		return
+	}
+
+	switch cmd := stmt.Cmd.(type) {
+	case *syntax.CallExpr:
+		w.call(cmd)
+	case *syntax.BinaryCmd:
+		w.stmt(cmd.X)
+		w.setOperator(cmd.Op.String())
+		w.stmt(cmd.Y)
+	case *syntax.Subshell:
+		w.compound++
+		w.stmts(cmd.Stmts)
+		w.compound--
+	case *syntax.Block:
+		w.compound++
+		w.stmts(cmd.Stmts)
+		w.compound--
+	case *syntax.TimeClause:
+		before := len(w.segments)
+		w.stmt(cmd.Stmt)
+		for i := before; i < len(w.segments); i++ {
+			w.segments[i].Wrappers = append([]string{"time"}, w.segments[i].Wrappers...)
+		}
+	default:
+		// Loops, conditionals and the like: collect the commands inside them.
+		// Which of them ran, and how often, isn't known.
+		w.compound++
+		syntax.Walk(cmd, func(node syntax.Node) bool {
+			if call, ok := node.(*syntax.CallExpr); ok {
+				w.call(call)
+				return false
+			}
+			return true
+		})
+		w.compound--
+	}
+}
+
+func (w *walker) call(call *syntax.CallExpr) {
+	var env []string
+	for _, assign := range call.Assigns {
+		if assign.Name != nil {
+			env = append(env, assign.Name.Value)
+		}
+	}
+
+	words := make([]string, 0, len(call.Args))
+	spans := make([]Span, 0, len(call.Args))
+	for _, word := range call.Args {
+		words = append(words, wordString(word))
+		spans = append(spans, Span{Start: int(word.Pos().Offset()), End: int(word.End().Offset())})
+	}
+
+	segment := newSegment(w.slice(call), words, spans)
+	segment.Env = append(env, segment.Env...)
+	segment.Pipeline = w.pipeline
+	segment.Compound = w.compound > 0
+	w.segments = append(w.segments, segment)
+}
+
+// setOperator records the operator following the most recent segment
+func (w *walker) setOperator(op string) {
+	if len(w.segments) == 0 {
+		return
+	}
+	last := &w.segments[len(w.segments)-1]
+	if last.Operator == "" {
+		last.Operator = op
+	}
+	if op != "|" && op != "|&" {
+		w.pipeline++
+	}
+}
+
+func (w *walker) slice(node syntax.Node) string {
+	start, end := int(node.Pos().Offset()), int(node.End().Offset())
+	if start < 0 || end > len(w.source) || start > end {
+		return ""
+	}
+	return w.source[start:end]
+}
+
+// wordString returns the literal value of a word, or its source text when it
+// contains expansions.
+func wordString(word *syntax.Word) string {
+	if lit := word.Lit(); lit != "" {
+		return lit
+	}
+
+	var buf bytes.Buffer
+	syntax.NewPrinter().Print(&buf, word)
+	text := buf.String()
+
+	// Unquote simple quoted words so `git "push"` still reads as push
+	if len(text) >= 2 && (text[0] == '\'' || text[0] == '"') && text[len(text)-1] == text[0] {
+		inner := text[1 : len(text)-1]
+		if !strings.ContainsAny(inner, "$`\\'\"") {
+			return inner
+		}
+	}
+	return text
+}
+
+// newSegment strips env assignments and wrapper commands off the front of a
+// word list.
+func newSegment(raw string, words []string, spans []Span) Segment {
+	segment := Segment{Raw: raw}
+
+	for len(words) > 0 {
+		if name, ok := assignmentName(words[0]); ok {
+			segment.Env = append(segment.Env, name)
+			words, spans = words[1:], spans[1:]
+			continue
+		}
+
+		wrapper := filepath.Base(words[0])
+		skip, isWrapper := wrapperArgs(wrapper, words[1:])
+		if !isWrapper {
+			break
+		}
+		segment.Wrappers = append(segment.Wrappers, wrapper)
+		words, spans = words[1+skip:], spans[1+skip:]
+	}
+
+	segment.Args = words
+	segment.Spans = spans
+	return segment
+}
+
+// fieldSpans finds each of the whitespace-separated fields in command
+func fieldSpans(command string, fields []string) []Span {
+	spans := make([]Span, 0, len(fields))
+	offset := 0
+	for _, field := range fields {
+		start := offset + strings.Index(command[offset:], field)
+		offset = start + len(field)
+		spans = append(spans, Span{Start: start, End: offset})
+	}
+	return spans
+}
+
+func assignmentName(word string) (string, bool) {
+	idx := strings.Index(word, "=")
+	if idx <= 0 {
+		return "", false
+	}
+	name := word[:idx]
+	for i, r := range name {
+		if !(r == '_' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || i > 0 && r >= '0' && r <= '9') {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// wrapperOptions lists, for each wrapper command, the options that consume a
+// following value. Other leading options are skipped on their own.
+var wrapperOptions = map[string][]string{
+	"sudo":    {"-u", "-g", "-C", "-h", "-p", "-U", "-r", "-t", "-D"},
+	"doas":    {"-u", "-C"},
+	"env":     {"-u", "-C", "-S"},
+	"time":    {"-f", "-o"},
+	"nohup":   {},
+	"nice":    {"-n"},
+	"ionice":  {"-c", "-n", "-p"},
+	"xargs":   {"-I", "-n", "-P", "-d", "-L", "-s", "-E", "-a"},
+	"command": {},
+	"builtin": {},
+	"exec":    {"-a"},
+	"stdbuf":  {"-i", "-o", "-e"},
+	"timeout": {"-s", "-k"},
+	"watch":   {"-n", "-d"},
+}
+
+// wrapperArgs reports whether name is a wrapper and how many of args belong
+// to the wrapper itself rather than the wrapped command.
+func wrapperArgs(name string, args []string) (int, bool) {
+	valued, ok := wrapperOptions[name]
+	if !ok {
+		return 0, false
+	}
+
+	skip := 0
+	for skip < len(args) {
+		arg := args[skip]
+		switch {
+		case arg == "--":
+			return skip + 1, true
+		case strings.HasPrefix(arg, "-"):
+			skip++
+			if contains(valued, arg) {
+				skip++
+			}
+		case name == "env":
+			// env takes its own NAME=value assignments before the command
+			if _, isAssign := assignmentName(arg); !isAssign {
+				return skip, true
+			}
+			skip++
+		case name == "timeout":
+			// The first positional argument is the duration
+			return skip + 1, true
+		default:
+			return skip, true
+		}
+	}
+	return skip, true
+}
+
+// quietCommands almost never fail on their own; they are usually trailing
+// status messages or pass-through pipeline stages.
+var quietCommands = []string{
+	"echo", "printf", "true", ":", "notify-send", "say", "tput", "clear",
+	"tee", "cat", "less", "more", "head", "tail", "wc", "sort", "uniq", "column",
+}
+
+// likelyFailed picks the segment most likely responsible for a non-zero exit:
+// the last one that actually does something, skipping trailing echo-style
+// commands and pass-through pipeline stages.
+func likelyFailed(segments []Segment) int {
+	for i := len(segments) - 1; i >= 0; i-- {
+		if !contains(quietCommands, segments[i].Executable()) {
+			return i
+		}
+	}
+	if len(segments) == 0 {
+		return 0
+	}
+	return len(segments) - 1
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package cmdline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sigpipe is the status of a stage killed because a later stage stopped
+// reading, as `yes | head -1` does. It is a consequence, not a cause.
+const sigpipe = 141
+
+// ParsePipestatus parses the exit status of each pipeline stage as the hooks
+// report it, e.g. "1 0" from bash's "${PIPESTATUS[*]}". An empty value yields
+// no statuses.
+func ParsePipestatus(value string) ([]int, error) {
+	fields := strings.Fields(value)
+	codes := make([]int, 0, len(fields))
+	for _, field := range fields {
+		code, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pipeline status %q", value)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// ApplyPipestatus records the status of each stage of the pipeline that ran
+// last, given the per-stage codes and the overall exit status the shell
+// reported, and makes the stage that failed the failed segment. It reports
+// false and changes nothing when the codes can't be matched to a pipeline:
+// a single code, codes that don't explain the exit status, no pipeline with
+// that many stages, or a compound statement where the pipeline would be,
+// whose commands aren't stages of their own.
+func (c *Command) ApplyPipestatus(codes []int, exit int) bool {
+	if len(codes) < 2 || !explains(codes, exit) {
+		return false
+	}
+
+	// The shell reports the pipeline that ran last, which is the last one
+	// with that many stages unless a later one was skipped by && or ||
+	start := -1
+	for i := len(c.Segments) - 1; i >= 0; i-- {
+		stages := c.pipeline(c.Segments[i].Pipeline)
+		if c.compound(stages) {
+			return false
+		}
+		if len(stages) == len(codes) {
+			start = stages[0]
+			break
+		}
+		i = stages[0]
+	}
+	if start < 0 {
+		return false
+	}
+
+	for i, code := range codes {
+		status := code
+		c.Segments[start+i].Status = &status
+	}
+	c.Failed = start + failedStage(codes)
+	return true
+}
+
+// Stages returns the segments the shell reported a status for, in order.
+func (c *Command) Stages() []Segment {
+	var stages []Segment
+	for _, segment := range c.Segments {
+		if segment.Status != nil {
+			stages = append(stages, segment)
+		}
+	}
+	return stages
+}
+
+// pipeline returns the indexes of the segments in pipeline p
+func (c *Command) pipeline(p int) []int {
+	var indexes []int
+	for i, segment := range c.Segments {
+		if segment.Pipeline == p {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// compound reports whether any of the segments is part of a compound statement
+func (c *Command) compound(indexes []int) bool {
+	for _, i := range indexes {
+		if c.Segments[i].Compound {
+			return true
+		}
+	}
+	return false
+}
+
+// explains reports whether a pipeline with these stage codes could have
+// exited with exit: the last code normally, the last non-zero one under
+// pipefail.
+func explains(codes []int, exit int) bool {
+	if exit == codes[len(codes)-1] {
+		return true
+	}
+	for i := len(codes) - 1; i >= 0; i-- {
+		if codes[i] != 0 {
+			return codes[i] == exit
+		}
+	}
+	return false
+}
+
+// failedStage picks the stage that caused a pipeline failure: the first one
+// that failed other than by SIGPIPE, since later stages usually fail for
+// lack of input. The last stage is blamed when none failed.
+func failedStage(codes []int) int {
+	broken := -1
+	for i, code := range codes {
+		switch {
+		case code == sigpipe && broken < 0:
+			broken = i
+		case code != 0 && code != sigpipe:
+			return i
+		}
+	}
+	if broken >= 0 {
+		return broken
+	}
+	return len(codes) - 1
+}
@@ -114,6 +114,16 @@ func FormatParrotOutput(personality, response string, enhanced bool) string {
 	return fmt.Sprintf("%s %s", parrotEmoji, coloredResponse)
 }
 
+// FormatTip formats a fix suggestion as a dim second line under the roast
+func FormatTip(tip string) string {
+	return "   " + Colorize(Dim, "💡 "+tip)
+}
+
+// FormatCommand formats a command the parrot offers to run
+func FormatCommand(command string) string {
+	return "   ➜ " + Colorize(Bold, command)
+}
+
 // formatEnhancedOutput creates fancy formatted output with personality-specific styling
 func formatEnhancedOutput(style ParrotStyle, response string) string {
 	var output strings.Builder
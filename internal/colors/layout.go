@@ -0,0 +1,191 @@
+package colors
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"parrot/internal/style"
+)
+
+// defaultWidth is assumed when the terminal width can't be determined
+const defaultWidth = 80
+
+// maxBubbleWidth keeps speech bubbles readable on very wide terminals
+const maxBubbleWidth = 60
+
+// asciiParrot is drawn beside the response in the ascii-parrot layout
+var asciiParrot = []string{
+	`   \\   `,
+	`   (o>  `,
+	`\\_//)  `,
+	` \_/_)  `,
+	`  _|_   `,
+}
+
+// TerminalWidth returns the width of the terminal parrot prints to. The hooks
+// pass $COLUMNS, which wins over asking the terminal.
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width := terminalWidth(os.Stdout); width > 0 {
+		return width
+	}
+	return defaultWidth
+}
+
+// FormatResponse lays out a possibly multi-line response to fit the terminal
+// and colors it for the personality. Colors are skipped when colored is false
+// or the output isn't a terminal.
+func FormatResponse(personality, response, layout string, enhanced, colored bool) string {
+	s, exists := Styles[personality]
+	if !exists {
+		s = Styles["default"]
+	}
+	paint := func(color, text string) string {
+		if !colored {
+			return text
+		}
+		return Colorize(color, text)
+	}
+
+	lines := strings.Split(response, "\n")
+	width := TerminalWidth()
+
+	switch layout {
+	case style.LayoutBubble:
+		return formatBubble(lines, min(width-4, maxBubbleWidth), s, paint)
+	case style.LayoutParrot:
+		return formatASCIIParrot(lines, width-len(asciiParrot[0])-1, s, paint)
+	}
+
+	if len(lines) == 1 && enhanced && colored && ColorEnabled() {
+		return formatEnhancedOutput(s, response)
+	}
+
+	// The parrot starts the first line; the rest line up under the text
+	var output strings.Builder
+	for i, line := range wrapLines(lines, width-3) {
+		if i == 0 {
+			output.WriteString(paint(s.Parrot, "🦜") + " ")
+		} else {
+			output.WriteString("\n   ")
+		}
+		output.WriteString(paint(s.Response, line))
+	}
+	return output.String()
+}
+
+// formatBubble draws the response in a cowsay-style speech bubble
+func formatBubble(lines []string, width int, s ParrotStyle, paint func(color, text string) string) string {
+	wrapped := wrapLines(lines, width)
+	inner := 0
+	for _, line := range wrapped {
+		inner = max(inner, displayWidth(line))
+	}
+
+	var output strings.Builder
+	output.WriteString(paint(s.Accent, " "+strings.Repeat("_", inner+2)) + "\n")
+	for i, line := range wrapped {
+		left, right := "|", "|"
+		switch {
+		case len(wrapped) == 1:
+			left, right = "<", ">"
+		case i == 0:
+			left, right = "/", "\\"
+		case i == len(wrapped)-1:
+			left, right = "\\", "/"
+		}
+		padding := strings.Repeat(" ", inner-displayWidth(line))
+		output.WriteString(paint(s.Accent, left) + " " + paint(s.Response, line) + padding + " " + paint(s.Accent, right) + "\n")
+	}
+	output.WriteString(paint(s.Accent, " "+strings.Repeat("-", inner+2)) + "\n")
+	output.WriteString(paint(s.Accent, "    \\") + "\n")
+	output.WriteString(paint(s.Accent, "     \\ ") + paint(s.Parrot, "🦜"))
+	return output.String()
+}
+
+// formatASCIIParrot draws an ASCII parrot with the response beside it
+func formatASCIIParrot(lines []string, width int, s ParrotStyle, paint func(color, text string) string) string {
+	wrapped := wrapLines(lines, width)
+	blank := strings.Repeat(" ", len(asciiParrot[0]))
+
+	var rows []string
+	for i := 0; i < max(len(asciiParrot), len(wrapped)+1); i++ {
+		art := blank
+		if i < len(asciiParrot) {
+			art = asciiParrot[i]
+		}
+		// Text starts level with the beak
+		text := ""
+		if i >= 1 && i-1 < len(wrapped) {
+			text = " " + paint(s.Response, wrapped[i-1])
+		}
+		rows = append(rows, strings.TrimRight(paint(s.Parrot, art)+text, " "))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// wrapLines word-wraps each line to width, splitting words that don't fit
+func wrapLines(lines []string, width int) []string {
+	width = max(width, 20)
+
+	var wrapped []string
+	for _, line := range lines {
+		current := ""
+		for _, word := range strings.Fields(line) {
+			for displayWidth(word) > width {
+				if current != "" {
+					wrapped = append(wrapped, current)
+					current = ""
+				}
+				head, tail := splitAt(word, width)
+				wrapped = append(wrapped, head)
+				word = tail
+			}
+			switch {
+			case current == "":
+				current = word
+			case displayWidth(current)+1+displayWidth(word) <= width:
+				current += " " + word
+			default:
+				wrapped = append(wrapped, current)
+				current = word
+			}
+		}
+		wrapped = append(wrapped, current)
+	}
+	return wrapped
+}
+
+// splitAt cuts text after width columns
+func splitAt(text string, width int) (string, string) {
+	columns := 0
+	for i, r := range text {
+		columns += runeWidth(r)
+		if columns > width {
+			return text[:i], text[i:]
+		}
+	}
+	return text, ""
+}
+
+// displayWidth approximates the columns text occupies; emoji take two
+func displayWidth(text string) int {
+	columns := 0
+	for _, r := range text {
+		columns += runeWidth(r)
+	}
+	return columns
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, r >= 0x2600 && r <= 0x27BF:
+		return 2
+	case r == 0xFE0F || r == 0x200D:
+		return 0
+	}
+	return 1
+}
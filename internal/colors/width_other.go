@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package colors
+
+import "os"
+
+// terminalWidth can't ask the terminal on this platform
+func terminalWidth(file *os.File) int {
+	return 0
+}
@@ -17,6 +17,33 @@ type Config struct {
 	
 	// General Settings
 	General GeneralConfig `toml:"general"`
+	
+	// Shell session context
+	Session SessionConfig `toml:"session"`
+	
+	// Command classification rules (checked before the built-in table)
+	Classifier ClassifierConfig `toml:"classifier"`
+	
+	// Secret masking before prompts leave the machine
+	Redaction RedactionConfig `toml:"redaction"`
+	
+	// Rules restricting which backends may see a command
+	Policy PolicyConfig `toml:"policy"`
+	
+	// Failures that never get a response
+	Suppress SuppressConfig `toml:"suppress"`
+	
+	// How often the parrot speaks up
+	Frequency FrequencyConfig `toml:"frequency"`
+	
+	// Escalation on losing streaks and congratulations when they end
+	Streak StreakConfig `toml:"streak"`
+	
+	// Commentary on successful commands that took too long
+	Slow SlowConfig `toml:"slow"`
+	
+	// Fix suggestions printed under the roast
+	Tips TipsConfig `toml:"tips"`
 }
 
 type APIConfig struct {
@@ -37,11 +64,19 @@ type LocalConfig struct {
 }
 
 type GeneralConfig struct {
-	Personality  string `toml:"personality"`   // "savage", "sarcastic", "mild"
-	FallbackMode bool   `toml:"fallback_mode"` // Use hardcoded responses only
-	Debug        bool   `toml:"debug"`         // Debug logging
-	Colors       bool   `toml:"colors"`        // Enable colored output
-	Enhanced     bool   `toml:"enhanced"`      // Enhanced formatting with borders/emphasis
+	Personality   string `toml:"personality"`    // "savage", "sarcastic", "mild"
+	FallbackMode  bool   `toml:"fallback_mode"`  // Use hardcoded responses only
+	FallbackStyle string `toml:"fallback_style"` // Offline responses: "lines", "grammar" or "mixed"
+	Style         string `toml:"style"`          // "one-liner", "haiku", "limerick", "cowsay", "ascii-parrot"
+	Language      string `toml:"language"`       // "en", "de", "es", "ja"; empty or "auto" follows LANG
+	Debug         bool   `toml:"debug"`          // Debug logging
+	Colors        bool   `toml:"colors"`         // Enable colored output
+	Enhanced      bool   `toml:"enhanced"`       // Enhanced formatting with borders/emphasis
+}
+
+type SessionConfig struct {
+	Transcript      bool `toml:"transcript"`       // Include recent session commands in prompts
+	TranscriptLimit int  `toml:"transcript_limit"` // Maximum number of prior commands to include
 }
 
 // Default configuration
@@ -63,11 +98,49 @@ func DefaultConfig() *Config {
 			Timeout:  5,  // Reduced from 30 to 5 seconds for responsiveness
 		},
 		General: GeneralConfig{
-			Personality:  "savage",
-			FallbackMode: false,
-			Debug:        false,
-			Colors:       true,
-			Enhanced:     false,
+			Personality:   "savage",
+			FallbackMode:  false,
+			FallbackStyle: "lines",
+			Style:         "one-liner",
+			Debug:         false,
+			Colors:        true,
+			Enhanced:      false,
+		},
+		Session: SessionConfig{
+			Transcript:      true,
+			TranscriptLimit: 5,
+		},
+		Redaction: RedactionConfig{
+			Enabled:     true,
+			RedactLocal: false,
+		},
+		Policy: PolicyConfig{
+			Builtin: true,
+		},
+		Suppress: SuppressConfig{
+			Builtin:    true,
+			IgnoreFile: true,
+		},
+		Frequency: FrequencyConfig{
+			MinInterval: 0,
+			Probability: 1,
+			Burst:       0,
+			BurstWindow: 600,
+		},
+		Streak: StreakConfig{
+			Enabled:    true,
+			Escalate:   3,
+			StuckAfter: 10,
+			Redemption: 2,
+		},
+		Slow: SlowConfig{
+			Enabled:   true,
+			Threshold: 300,
+			Builtin:   true,
+		},
+		Tips: TipsConfig{
+			Enabled:       true,
+			Personalities: []string{"mild", "sarcastic"},
 		},
 	}
 }
@@ -100,6 +173,125 @@ func GetConfigPaths() []string {
 	return paths
 }
 
+type ClassifierConfig struct {
+	Rules []ClassifierRule `toml:"rules"`
+}
+
+type ClassifierRule struct {
+	Type        string   `toml:"type"`        // Hierarchical type, e.g. "kubectl.apply"
+	Executables []string `toml:"executables"` // Match on the command's executable
+	Subcommands []string `toml:"subcommands"` // Match on the first non-flag argument
+	Pattern     string   `toml:"pattern"`     // Regular expression over the failed segment
+	Subtype     bool     `toml:"subtype"`     // Append the subcommand to type (git -> git.push)
+}
+
+type TipsConfig struct {
+	Enabled       bool     `toml:"enabled"`       // Print a fix suggestion under the roast
+	Personalities []string `toml:"personalities"` // Personalities that give tips
+}
+
+// EnabledFor reports whether tips are shown for a personality.
+func (t TipsConfig) EnabledFor(personality string) bool {
+	if !t.Enabled {
+		return false
+	}
+	for _, p := range t.Personalities {
+		if p == personality {
+			return true
+		}
+	}
+	return false
+}
+
+type RedactionConfig struct {
+	Enabled     bool     `toml:"enabled"`      // Mask secrets before prompts reach the API backend
+	RedactLocal bool     `toml:"redact_local"` // Also mask secrets for the local backend
+	Patterns    []string `toml:"patterns"`     // Extra regexes; a (?P<secret>...) group limits what is masked
+}
+
+type PolicyConfig struct {
+	Builtin bool         `toml:"builtin"` // Apply built-in rules (secret managers stay local)
+	Rules   []PolicyRule `toml:"rules"`
+}
+
+type PolicyRule struct {
+	Name         string   `toml:"name"`
+	Command      string   `toml:"command"`       // Glob over the command text
+	CommandRegex string   `toml:"command_regex"` // Regular expression over the command text
+	Executables  []string `toml:"executables"`   // Any segment runs one of these
+	Cwd          string   `toml:"cwd"`           // Glob over the working directory (and below)
+	Type         string   `toml:"type"`          // Glob over the command type, e.g. "kubectl.*"
+	Action       string   `toml:"action"`        // "allow", "local-only", "fallback-only", "silent"
+}
+
+type SuppressConfig struct {
+	Builtin    bool           `toml:"builtin"`     // Ignore expected exits: Ctrl-C, grep finding nothing, test, diff, false
+	IgnoreFile bool           `toml:"ignore_file"` // Read .parrotignore files from the working directory up
+	Rules      []SuppressRule `toml:"rules"`
+}
+
+type SuppressRule struct {
+	Name         string   `toml:"name"`
+	Command      string   `toml:"command"`       // Glob over the command text
+	CommandRegex string   `toml:"command_regex"` // Regular expression over the command text
+	Executables  []string `toml:"executables"`   // The failed segment runs one of these
+	ExitCodes    []int    `toml:"exit_codes"`    // Only these exit codes; empty means any
+	Cwd          string   `toml:"cwd"`           // Glob over the working directory (and below)
+}
+
+type FrequencyConfig struct {
+	MinInterval int     `toml:"min_interval"` // Seconds between roasts in one shell session
+	Probability float64 `toml:"probability"`  // Chance that a failure gets roasted, 0 to 1
+	Burst       int     `toml:"burst"`        // Roasts allowed per burst_window; 0 means no limit
+	BurstWindow int     `toml:"burst_window"` // Seconds for a used-up burst budget to refill
+}
+
+type StreakConfig struct {
+	Enabled    bool `toml:"enabled"`     // Track failure streaks in each shell session
+	Escalate   int  `toml:"escalate"`    // Failures in a row per step up in tone (mild → sarcastic → savage); 0 never escalates
+	StuckAfter int  `toml:"stuck_after"` // Minutes stuck on one command per step up in tone; 0 ignores time
+	Redemption int  `toml:"redemption"`  // Failed attempts before a success earns a backhanded congratulation; 0 never
+}
+
+type SlowConfig struct {
+	Enabled   bool       `toml:"enabled"`   // Comment on successful commands that ran too long
+	Threshold int        `toml:"threshold"` // Seconds before a command counts as slow; 0 only uses the rules
+	Builtin   bool       `toml:"builtin"`   // Never time interactive programs: editors, pagers, shells, ssh
+	Rules     []SlowRule `toml:"rules"`     // Thresholds for particular commands; the first match wins
+}
+
+type SlowRule struct {
+	Name        string   `toml:"name"`
+	Command     string   `toml:"command"`     // Glob over the command text
+	Executables []string `toml:"executables"` // Any segment runs one of these
+	Type        string   `toml:"type"`        // Glob over the command type, e.g. "docker.*"
+	Threshold   int      `toml:"threshold"`   // Seconds; 0 means never slow
+}
+
+// StateDir returns the directory used for runtime state such as session
+// transcripts. It honours XDG_STATE_HOME and defaults to ~/.local/state/parrot.
+func StateDir() string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "parrot")
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".local", "state", "parrot")
+	}
+	return filepath.Join(os.TempDir(), "parrot")
+}
+
+// DataDir returns the directory for user-installed data such as roast packs.
+// It honours XDG_DATA_HOME and defaults to ~/.local/share/parrot.
+func DataDir() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "parrot")
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".local", "share", "parrot")
+	}
+	return filepath.Join(os.TempDir(), "parrot")
+}
+
 // Load configuration from first available config file
 func LoadConfig() (*Config, error) {
 	config := DefaultConfig()
@@ -152,6 +344,15 @@ func loadFromEnv(config *Config) {
 	if os.Getenv("PARROT_FALLBACK_ONLY") == "true" {
 		config.General.FallbackMode = true
 	}
+	if style := os.Getenv("PARROT_FALLBACK_STYLE"); style != "" {
+		config.General.FallbackStyle = style
+	}
+	if language := os.Getenv("PARROT_LANGUAGE"); language != "" {
+		config.General.Language = language
+	}
+	if responseStyle := os.Getenv("PARROT_STYLE"); responseStyle != "" {
+		config.General.Style = responseStyle
+	}
 	if os.Getenv("PARROT_DEBUG") == "true" {
 		config.General.Debug = true
 	}
@@ -161,6 +362,26 @@ func loadFromEnv(config *Config) {
 	if os.Getenv("PARROT_ENHANCED") == "true" {
 		config.General.Enhanced = true
 	}
+	
+	// Session configuration
+	if os.Getenv("PARROT_NO_TRANSCRIPT") == "true" {
+		config.Session.Transcript = false
+	}
+	
+	// Streak configuration
+	if os.Getenv("PARROT_NO_STREAK") == "true" {
+		config.Streak.Enabled = false
+	}
+	
+	// Slow command configuration
+	if os.Getenv("PARROT_NO_SLOW") == "true" {
+		config.Slow.Enabled = false
+	}
+	
+	// Tips configuration
+	if os.Getenv("PARROT_NO_TIPS") == "true" {
+		config.Tips.Enabled = false
+	}
 }
 
 // Create a sample config file
@@ -0,0 +1,269 @@
+package exitcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Class groups exit codes that deserve the same kind of response.
+type Class string
+
+const (
+	ClassSuccess       Class = "success"
+	ClassFailure       Class = "failure"        // Plain, unexplained failure
+	ClassUsage         Class = "usage"          // Bad arguments or syntax
+	ClassNotFound      Class = "not_found"      // Command not found (127)
+	ClassNotExecutable Class = "not_executable" // Found but couldn't run (126)
+	ClassPermission    Class = "permission"     // Permission denied
+	ClassNetwork       Class = "network"        // DNS, connection and protocol errors
+	ClassTimeout       Class = "timeout"        // Gave up waiting
+	ClassInterrupted   Class = "interrupted"    // Ctrl-C, Ctrl-\
+	ClassKilled        Class = "killed"         // SIGKILL, usually the OOM killer
+	ClassTerminated    Class = "terminated"     // SIGTERM, SIGHUP
+	ClassBrokenPipe    Class = "broken_pipe"    // SIGPIPE, reader went away
+	ClassCrash         Class = "crash"          // SIGSEGV, SIGABRT, panics
+	ClassSignal        Class = "signal"         // Any other signal
+	ClassNoMatch       Class = "no_match"       // Nothing found, not really an error
+	ClassDifference    Class = "difference"     // Inputs differ, not really an error
+)
+
+// Classes lists every class, for validating user input.
+var Classes = []Class{
+	ClassSuccess, ClassFailure, ClassUsage, ClassNotFound, ClassNotExecutable,
+	ClassPermission, ClassNetwork, ClassTimeout, ClassInterrupted, ClassKilled,
+	ClassTerminated, ClassBrokenPipe, ClassCrash, ClassSignal, ClassNoMatch,
+	ClassDifference,
+}
+
+// ValidClass reports whether class is one of Classes.
+func ValidClass(class Class) bool {
+	for _, known := range Classes {
+		if class == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Info is the interpretation of an exit code, optionally in the context of
+// the tool that produced it.
+type Info struct {
+	Code    int
+	Class   Class
+	Signal  string // Signal name for 128+N codes, e.g. "SIGKILL"
+	Meaning string // Short human-readable explanation
+	Tool    string // Set when a tool-specific meaning was used
+	Benign  bool   // Exit is expected behaviour rather than a real failure
+}
+
+// Summary renders the interpretation on one line.
+func (i Info) Summary() string {
+	summary := fmt.Sprintf("%d: %s", i.Code, i.Meaning)
+	if i.Tool != "" {
+		summary += fmt.Sprintf(" (%s)", i.Tool)
+	}
+	return summary
+}
+
+// Parse converts an exit code argument to an int, accepting surrounding space.
+func Parse(code string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(code))
+	if err != nil {
+		return 0, fmt.Errorf("invalid exit code %q", code)
+	}
+	return value, nil
+}
+
+// Interpret explains an exit code. If executable is given and the tool has
+// its own meaning for the code, that takes precedence over the conventions.
+func Interpret(code int, executable string) Info {
+	if toolCodes, ok := toolMeanings[executable]; ok {
+		if meaning, ok := toolCodes[code]; ok {
+			meaning.Code = code
+			meaning.Tool = executable
+			return meaning
+		}
+	}
+
+	if code > 128 && code < 128+65 {
+		return interpretSignal(code)
+	}
+
+	if meaning, ok := conventional[code]; ok {
+		meaning.Code = code
+		return meaning
+	}
+
+	return Info{Code: code, Class: ClassFailure, Meaning: "general failure"}
+}
+
+// Signal returns the name of signal number n, or "" if it isn't well known.
+func Signal(n int) string {
+	return signalNames[n]
+}
+
+func interpretSignal(code int) Info {
+	number := code - 128
+	name := signalNames[number]
+	if name == "" {
+		name = fmt.Sprintf("signal %d", number)
+	}
+
+	info := Info{
+		Code:    code,
+		Class:   ClassSignal,
+		Signal:  name,
+		Meaning: fmt.Sprintf("terminated by %s", name),
+	}
+	if meaning, ok := signalMeanings[number]; ok {
+		info.Class = meaning.Class
+		info.Meaning = fmt.Sprintf("terminated by %s: %s", name, meaning.Meaning)
+		info.Benign = meaning.Benign
+	}
+	return info
+}
+
+// signalNames uses Linux numbering
+var signalNames = map[int]string{
+	1: "SIGHUP", 2: "SIGINT", 3: "SIGQUIT", 4: "SIGILL", 5: "SIGTRAP",
+	6: "SIGABRT", 7: "SIGBUS", 8: "SIGFPE", 9: "SIGKILL", 10: "SIGUSR1",
+	11: "SIGSEGV", 12: "SIGUSR2", 13: "SIGPIPE", 14: "SIGALRM", 15: "SIGTERM",
+	24: "SIGXCPU", 25: "SIGXFSZ",
+}
+
+var signalMeanings = map[int]Info{
+	1:  {Class: ClassTerminated, Meaning: "the terminal went away"},
+	2:  {Class: ClassInterrupted, Meaning: "interrupted with Ctrl-C", Benign: true},
+	3:  {Class: ClassInterrupted, Meaning: "quit with Ctrl-\\"},
+	4:  {Class: ClassCrash, Meaning: "illegal instruction"},
+	6:  {Class: ClassCrash, Meaning: "aborted, usually a failed assertion"},
+	7:  {Class: ClassCrash, Meaning: "bus error"},
+	8:  {Class: ClassCrash, Meaning: "arithmetic error such as division by zero"},
+	9:  {Class: ClassKilled, Meaning: "killed outright, often by the OOM killer"},
+	11: {Class: ClassCrash, Meaning: "segmentation fault"},
+	13: {Class: ClassBrokenPipe, Meaning: "the reading end of a pipe closed early", Benign: true},
+	14: {Class: ClassTimeout, Meaning: "an alarm timer expired"},
+	15: {Class: ClassTerminated, Meaning: "asked to terminate"},
+	24: {Class: ClassKilled, Meaning: "CPU time limit exceeded"},
+	25: {Class: ClassKilled, Meaning: "file size limit exceeded"},
+}
+
+// conventional covers shell conventions and sysexits.h
+var conventional = map[int]Info{
+	0:   {Class: ClassSuccess, Meaning: "success"},
+	1:   {Class: ClassFailure, Meaning: "general failure"},
+	2:   {Class: ClassUsage, Meaning: "misuse of a command or shell builtin"},
+	64:  {Class: ClassUsage, Meaning: "command line usage error (EX_USAGE)"},
+	65:  {Class: ClassFailure, Meaning: "bad input data (EX_DATAERR)"},
+	66:  {Class: ClassFailure, Meaning: "input file missing or unreadable (EX_NOINPUT)"},
+	67:  {Class: ClassFailure, Meaning: "no such user (EX_NOUSER)"},
+	68:  {Class: ClassNetwork, Meaning: "no such host (EX_NOHOST)"},
+	69:  {Class: ClassFailure, Meaning: "service unavailable (EX_UNAVAILABLE)"},
+	70:  {Class: ClassCrash, Meaning: "internal software error (EX_SOFTWARE)"},
+	71:  {Class: ClassFailure, Meaning: "system error (EX_OSERR)"},
+	72:  {Class: ClassFailure, Meaning: "critical OS file missing (EX_OSFILE)"},
+	73:  {Class: ClassPermission, Meaning: "can't create output file (EX_CANTCREAT)"},
+	74:  {Class: ClassFailure, Meaning: "input/output error (EX_IOERR)"},
+	75:  {Class: ClassTimeout, Meaning: "temporary failure, try again (EX_TEMPFAIL)"},
+	76:  {Class: ClassNetwork, Meaning: "remote protocol error (EX_PROTOCOL)"},
+	77:  {Class: ClassPermission, Meaning: "permission denied (EX_NOPERM)"},
+	78:  {Class: ClassUsage, Meaning: "configuration error (EX_CONFIG)"},
+	124: {Class: ClassTimeout, Meaning: "timed out (timeout(1))"},
+	126: {Class: ClassNotExecutable, Meaning: "command found but not executable"},
+	127: {Class: ClassNotFound, Meaning: "command not found"},
+	128: {Class: ClassUsage, Meaning: "invalid argument to exit"},
+	255: {Class: ClassFailure, Meaning: "exit status out of range"},
+}
+
+// toolMeanings overrides the conventions for tools with their own exit codes.
+var toolMeanings = map[string]map[int]Info{
+	"curl": {
+		3:  {Class: ClassUsage, Meaning: "malformed URL"},
+		5:  {Class: ClassNetwork, Meaning: "couldn't resolve proxy"},
+		6:  {Class: ClassNetwork, Meaning: "couldn't resolve host"},
+		7:  {Class: ClassNetwork, Meaning: "failed to connect to host"},
+		22: {Class: ClassNetwork, Meaning: "HTTP error status (400 or above)"},
+		28: {Class: ClassTimeout, Meaning: "operation timed out"},
+		35: {Class: ClassNetwork, Meaning: "TLS handshake failed"},
+		47: {Class: ClassNetwork, Meaning: "too many redirects"},
+		52: {Class: ClassNetwork, Meaning: "server returned nothing"},
+		56: {Class: ClassNetwork, Meaning: "failure receiving network data"},
+		60: {Class: ClassNetwork, Meaning: "TLS certificate verification failed"},
+	},
+	"wget": {
+		2: {Class: ClassUsage, Meaning: "command line parse error"},
+		3: {Class: ClassFailure, Meaning: "file I/O error"},
+		4: {Class: ClassNetwork, Meaning: "network failure"},
+		5: {Class: ClassNetwork, Meaning: "TLS verification failure"},
+		6: {Class: ClassPermission, Meaning: "authentication failure"},
+		7: {Class: ClassNetwork, Meaning: "protocol error"},
+		8: {Class: ClassNetwork, Meaning: "server issued an error response"},
+	},
+	"docker": {
+		125: {Class: ClassFailure, Meaning: "the docker daemon itself failed"},
+		126: {Class: ClassNotExecutable, Meaning: "the container command couldn't be invoked"},
+		127: {Class: ClassNotFound, Meaning: "the container command wasn't found"},
+	},
+	"podman": {
+		125: {Class: ClassFailure, Meaning: "podman itself failed"},
+		126: {Class: ClassNotExecutable, Meaning: "the container command couldn't be invoked"},
+		127: {Class: ClassNotFound, Meaning: "the container command wasn't found"},
+	},
+	"grep": {
+		1: {Class: ClassNoMatch, Meaning: "no lines matched", Benign: true},
+		2: {Class: ClassFailure, Meaning: "an error occurred (bad pattern or unreadable file)"},
+	},
+	"rg": {
+		1: {Class: ClassNoMatch, Meaning: "no matches found", Benign: true},
+		2: {Class: ClassFailure, Meaning: "an error occurred"},
+	},
+	"diff": {
+		1: {Class: ClassDifference, Meaning: "the inputs differ", Benign: true},
+		2: {Class: ClassFailure, Meaning: "trouble reading the inputs"},
+	},
+	"cmp": {
+		1: {Class: ClassDifference, Meaning: "the files differ", Benign: true},
+	},
+	"test": {
+		1: {Class: ClassNoMatch, Meaning: "the condition was false", Benign: true},
+	},
+	"[": {
+		1: {Class: ClassNoMatch, Meaning: "the condition was false", Benign: true},
+	},
+	"false": {
+		1: {Class: ClassFailure, Meaning: "false always fails", Benign: true},
+	},
+	"git": {
+		128: {Class: ClassFailure, Meaning: "fatal error (not a repository, bad ref or remote problem)"},
+		129: {Class: ClassUsage, Meaning: "invalid usage"},
+	},
+	"ssh": {
+		255: {Class: ClassNetwork, Meaning: "connection or authentication failed"},
+	},
+	"make": {
+		2: {Class: ClassFailure, Meaning: "a recipe failed"},
+	},
+	"timeout": {
+		124: {Class: ClassTimeout, Meaning: "the command timed out"},
+		125: {Class: ClassFailure, Meaning: "timeout itself failed"},
+	},
+	"pytest": {
+		1: {Class: ClassFailure, Meaning: "some tests failed"},
+		2: {Class: ClassInterrupted, Meaning: "test run interrupted"},
+		4: {Class: ClassUsage, Meaning: "pytest usage error"},
+		5: {Class: ClassNoMatch, Meaning: "no tests were collected"},
+	},
+	"cargo": {
+		101: {Class: ClassCrash, Meaning: "a Rust program panicked or the build failed"},
+	},
+	"systemctl": {
+		3: {Class: ClassFailure, Meaning: "the unit is not active"},
+		4: {Class: ClassNotFound, Meaning: "no such unit"},
+	},
+	"rsync": {
+		23:  {Class: ClassFailure, Meaning: "partial transfer due to errors"},
+		24:  {Class: ClassFailure, Meaning: "some source files vanished"},
+		255: {Class: ClassNetwork, Meaning: "the remote shell connection failed"},
+	},
+}
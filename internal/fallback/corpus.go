@@ -0,0 +1,340 @@
+package fallback
+
+// Corpus holds the built-in lines by personality, then by command type or
+// exit class ("exit.<class>"). Command types fall through to their parents,
+// so "git" lines cover "git.push" unless it has its own.
+var Corpus = map[string]map[string][]Line{
+	"mild": {
+		"git": {
+			{Text: "Git command failed. Maybe check your remote branch?"},
+			{Text: "Oops, that didn't work. Double-check your git status."},
+			{Text: "Git hiccup detected. Have you tried git pull first?"},
+		},
+		"git.push": {
+			{Text: "Push rejected. A quick git pull --rebase usually sorts it out."},
+			{Text: "The remote said no. Is your branch tracking an upstream?"},
+		},
+		"nodejs": {
+			{Text: "NPM seems unhappy. Try clearing your cache?"},
+			{Text: "Node modules acting up. Maybe npm install again?"},
+			{Text: "Package installation hiccup. Check your package.json?"},
+		},
+		"docker": {
+			{Text: "Container seems upset. Check your Dockerfile?"},
+			{Text: "Docker command failed. Is the daemon running?"},
+			{Text: "Build didn't work. Maybe check those port mappings?"},
+		},
+		"http": {
+			{Text: "Request didn't go through. Check the URL?"},
+			{Text: "Network seems down. Try again in a moment?"},
+			{Text: "HTTP error detected. Is the server running?"},
+		},
+		"kubectl": {
+			{Text: "The cluster didn't like that. Check your current context?"},
+			{Text: "kubectl hiccup. Is the namespace right?"},
+		},
+		"terraform": {
+			{Text: "Terraform stumbled. Maybe run terraform init again?"},
+			{Text: "Plan didn't apply cleanly. Check the state lock?"},
+		},
+		"cargo": {
+			{Text: "The borrow checker has notes. Read them kindly."},
+			{Text: "Cargo build failed. The compiler message is usually right."},
+		},
+		"go": {
+			{Text: "Go build failed. Maybe a go mod tidy is in order?"},
+			{Text: "Unused import, perhaps? Go is picky like that."},
+		},
+		"python": {
+			{Text: "Python raised an exception. The traceback's last line is the clue."},
+			{Text: "Is the right virtualenv active?"},
+		},
+		"make": {
+			{Text: "A recipe failed. Scroll up for the first error."},
+			{Text: "Make stopped. Maybe a clean build helps?"},
+		},
+		"ssh": {
+			{Text: "Couldn't connect. Check the host and your keys?"},
+		},
+		"navigation": {
+			{Text: "That directory doesn't seem to exist. Tab completion helps!"},
+		},
+		"package": {
+			{Text: "Package manager trouble. Maybe refresh the package index?"},
+		},
+		"generic": {
+			{Text: "Command didn't work as expected. Check the syntax?"},
+			{Text: "Something went wrong. Maybe try the help flag?"},
+			{Text: "Error detected. Double-check your parameters?"},
+		},
+		"exit.not_found": {
+			{Text: "Command not found. A typo, perhaps?"},
+			{Text: "That command isn't installed, or isn't on your PATH."},
+			{Text: "Command not found. Did you mean {suggestion}?"},
+			{Text: "Close! I think you meant {suggestion}."},
+		},
+		"exit.not_executable": {
+			{Text: "Found it, but it isn't executable. chmod +x might help."},
+		},
+		"exit.permission": {
+			{Text: "Permission denied. Does this need sudo?"},
+		},
+		"exit.interrupted": {
+			{Text: "Interrupted. Take your time."},
+			{Text: "Ctrl-C noted. No harm done."},
+		},
+		"exit.killed": {
+			{Text: "Process was killed. It may have run out of memory."},
+		},
+		"exit.crash": {
+			{Text: "That crashed. A debugger might shed some light."},
+		},
+		"exit.timeout": {
+			{Text: "Timed out. Maybe the server is slow right now?"},
+		},
+		"exit.network": {
+			{Text: "Network trouble. Check your connection and the hostname?"},
+		},
+		"exit.usage": {
+			{Text: "The arguments didn't parse. The --help output may help."},
+		},
+		"exit.success": {
+			{Text: "It works now. Knew you would get there eventually."},
+			{Text: "There it is! Only took {attempts} tries."},
+			{Text: "Success at last. Those {stuck} were well spent, surely."},
+		},
+		"slow": {
+			{Text: "Done! That took a while, though. Maybe cache some of it?"},
+			{Text: "It worked, eventually. Time for a faster build?"},
+			{Text: "{duration} later, success. Hope there was coffee."},
+		},
+	},
+
+	"sarcastic": {
+		"git": {
+			{Text: "Git good? More like git rekt!", Weight: 2},
+			{Text: "Did you forget to pull again? Classic amateur move."},
+			{Text: "Another git genius strikes again!"},
+			{Text: "Your commits are as broken as your workflow."},
+			{Text: "Another git genius who forgot to pull first. Classic."},
+		},
+		"git.push": {
+			{Text: "Rejected! The remote has trust issues, and honestly, same."},
+			{Text: "Pushing without pulling. Bold strategy."},
+		},
+		"git.push.force": {
+			{Text: "Force push failed. Even brute force has standards."},
+			{Text: "--force: for when reading the error message is too much work."},
+		},
+		"nodejs": {
+			{Text: "NPM install failed? Shocking! Nobody saw that coming.", Weight: 2},
+			{Text: "Your package.json is crying. Fix it."},
+			{Text: "Node modules: where dependencies go to die."},
+			{Text: "Even npm doesn't want to deal with your code."},
+		},
+		"docker": {
+			{Text: "Docker container more like docker DISASTER!", Weight: 2},
+			{Text: "Even containers can't contain your incompetence."},
+			{Text: "Your Dockerfile needs therapy."},
+			{Text: "Container exit code: user error detected."},
+		},
+		"http": {
+			{Text: "404: Competence not found.", Weight: 2},
+			{Text: "Even the internet doesn't want to talk to you."},
+			{Text: "Connection refused? So is your logic."},
+			{Text: "HTTP status: 500 Internal User Error."},
+		},
+		"kubectl": {
+			{Text: "kubectl apply -f hope.yaml didn't work? Shocking."},
+			{Text: "Wrong context again? The cluster is thrilled."},
+			{Text: "CrashLoopBackOff is also a good description of your process."},
+		},
+		"terraform": {
+			{Text: "Terraform plan: destroy your confidence."},
+			{Text: "State lock held. Probably by your past mistakes."},
+		},
+		"cargo": {
+			{Text: "The borrow checker borrowed your dignity and isn't giving it back."},
+			{Text: "Fearless concurrency, fearful developer."},
+		},
+		"go": {
+			{Text: "if err != nil { return you }"},
+			{Text: "Unused variable? Go noticed. Go always notices."},
+		},
+		"python": {
+			{Text: "IndentationError: so is your career path."},
+			{Text: "Wrong virtualenv? Every. Single. Time."},
+		},
+		"make": {
+			{Text: "make: *** [you] Error 2"},
+			{Text: "Make couldn't make it. Neither could you."},
+		},
+		"ssh": {
+			{Text: "Permission denied (publickey). The server has taste."},
+			{Text: "Connection refused. The server saw you coming."},
+		},
+		"navigation": {
+			{Text: "cd to nowhere. A bold journey."},
+			{Text: "No such directory. Tab completion exists, you know."},
+		},
+		"package": {
+			{Text: "The package manager looked at your request and said no."},
+		},
+		"systemctl": {
+			{Text: "The service failed to start. Relatable."},
+		},
+		"generic": {
+			{Text: "Wow, you managed to break something simple. Impressive!", Weight: 2},
+			{Text: "Maybe try reading the manual... oh wait, who am I kidding?"},
+			{Text: "Error code says it all: user error!"},
+			{Text: "Have you tried turning your brain on and off again?"},
+		},
+		"exit.not_found": {
+			{Text: "Command not found. Typing is hard, I know."},
+			{Text: "That command doesn't exist, and neither does your attention to detail."},
+			{Text: "Did you mean {suggestion}, genius?"},
+			{Text: "`{executable}`? So close. It's {suggestion}."},
+			{Text: "Your fingers wanted {suggestion}. Your brain had other plans."},
+		},
+		"exit.not_executable": {
+			{Text: "Found it, can't run it. chmod +x is right there."},
+			{Text: "Permission to execute: denied. Permission to mock: granted."},
+		},
+		"exit.permission": {
+			{Text: "Permission denied. The computer has boundaries. Respect them."},
+		},
+		"exit.interrupted": {
+			{Text: "Ctrl-C: the universal sign of giving up."},
+			{Text: "Rage quit detected."},
+		},
+		"exit.killed": {
+			{Text: "Killed. Even the OOM killer had enough of you."},
+			{Text: "SIGKILL: the kernel's way of saying 'absolutely not'."},
+		},
+		"exit.broken_pipe": {
+			{Text: "Broken pipe. Call a plumber."},
+		},
+		"exit.crash": {
+			{Text: "Segfault! Memory safety is a lifestyle you haven't adopted."},
+			{Text: "It crashed. Spectacularly. Well done."},
+		},
+		"exit.timeout": {
+			{Text: "Timed out. Even the network got bored waiting for you."},
+		},
+		"exit.network": {
+			{Text: "The network called. It doesn't want to talk to you."},
+		},
+		"exit.usage": {
+			{Text: "Those flags aren't real. --help is, though."},
+		},
+		"exit.success": {
+			{Text: "It worked! Alert the press."},
+			{Text: "Only {attempts} tries. A new personal best, probably."},
+			{Text: "{stuck} of flailing, and it finally works. Inspiring."},
+			{Text: "Congratulations on doing what the docs said all along."},
+		},
+		"slow": {
+			{Text: "Well, that took long enough."},
+			{Text: "Finished! Civilizations have risen and fallen in less time."},
+			{Text: "{duration}. I aged a year waiting for it."},
+			{Text: "Success, delivered at the speed of continental drift."},
+		},
+	},
+
+	"savage": {
+		"git": {
+			{Text: "Git rejected your code harder than everyone rejects you.", Weight: 2},
+			{Text: "Your git skills are as non-existent as your social life."},
+			{Text: "Even git thinks you're a disappointment to developers."},
+		},
+		"git.push.force": {
+			{Text: "Force pushing like a toddler forcing a square peg. Still wrong."},
+		},
+		"nodejs": {
+			{Text: "NPM refuses to install anything for someone this incompetent.", Weight: 2},
+			{Text: "Your code is buggier than a Node.js 0.1 release."},
+			{Text: "Even npm's dependency hell is more organized than your brain."},
+		},
+		"docker": {
+			{Text: "Your containers crash faster than your career prospects.", Weight: 2},
+			{Text: "Docker can't contain the disaster that is your coding."},
+			{Text: "Even Docker Hub wouldn't host your garbage code."},
+		},
+		"http": {
+			{Text: "The internet collectively rejected you. Impressive.", Weight: 2},
+			{Text: "404 Error: Brain not found, never was found."},
+			{Text: "Your requests are as unwanted as your opinions."},
+		},
+		"kubectl": {
+			{Text: "Your pods are crash-looping, much like your decision making."},
+			{Text: "The cluster evicted your command. It has standards."},
+		},
+		"terraform": {
+			{Text: "Infrastructure as code, incompetence as a service."},
+		},
+		"cargo": {
+			{Text: "The borrow checker read your code and filed a restraining order."},
+		},
+		"go": {
+			{Text: "err != nil, and neither is your talent. Oh wait, it is nil."},
+		},
+		"python": {
+			{Text: "Traceback (most recent call last): your entire career."},
+		},
+		"make": {
+			{Text: "Make failed. You can't even follow a recipe."},
+		},
+		"ssh": {
+			{Text: "The server refused you. Machines have standards too."},
+		},
+		"navigation": {
+			{Text: "You got lost in your own filesystem. Incredible."},
+		},
+		"generic": {
+			{Text: "Your command failed harder than you failed at life.", Weight: 2},
+			{Text: "Error: User incompetence exceeds system limitations."},
+			{Text: "This failure defines your existence."},
+		},
+		"exit.not_found": {
+			{Text: "Command not found. Neither is your competence."},
+			{Text: "You invented a command that doesn't exist. Visionary. Wrong, but visionary."},
+			{Text: "It's {suggestion}. Spelling is not optional."},
+			{Text: "The word you were looking for was {suggestion}. Try typing it."},
+		},
+		"exit.not_executable": {
+			{Text: "Not executable. Much like your plans."},
+		},
+		"exit.permission": {
+			{Text: "Permission denied. The system knows who you are."},
+		},
+		"exit.interrupted": {
+			{Text: "Ctrl-C. Quitting is the one thing you're good at."},
+		},
+		"exit.killed": {
+			{Text: "The kernel killed it. Mercy, really."},
+		},
+		"exit.crash": {
+			{Text: "Segfault. Your code touched memory it had no business touching."},
+		},
+		"exit.timeout": {
+			{Text: "Timed out. Nothing wants to wait for you."},
+		},
+		"exit.network": {
+			{Text: "The network would rather be down than talk to you."},
+		},
+		"exit.usage": {
+			{Text: "You can't even pass arguments correctly. Remarkable."},
+		},
+		"exit.success": {
+			{Text: "Wow, it worked. Even a broken clock, etc."},
+			{Text: "Congrats on finally doing the bare minimum."},
+			{Text: "{attempts} attempts. Don't expect a medal."},
+			{Text: "{stuck} for that? The command ran; your dignity didn't."},
+		},
+		"slow": {
+			{Text: "That took so long I forgot what you were doing. So did you."},
+			{Text: "It worked. Eventually. Like your career."},
+			{Text: "{duration} for that? The heat death of the universe is jealous."},
+		},
+	},
+}
@@ -0,0 +1,377 @@
+package fallback
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"parrot/internal/classify"
+	"parrot/internal/cmdline"
+	"parrot/internal/config"
+	"parrot/internal/exitcode"
+	"parrot/internal/packs"
+)
+
+// historySize is how many recently shown lines are avoided
+const historySize = 16
+
+// slowKey holds the lines for successes that took too long
+const slowKey = "slow"
+
+// Styles of offline response.
+const (
+	StyleLines   = "lines"   // Built-in and pack lines
+	StyleGrammar = "grammar" // Lines generated from the grammars
+	StyleMixed   = "mixed"   // Either, at random
+)
+
+// generateAttempts is how often generation retries to avoid a recent line
+const generateAttempts = 5
+
+// Line is a canned response with a relative selection weight.
+type Line struct {
+	Text   string
+	Weight int
+}
+
+// Situation is the failure a line is picked for.
+type Situation struct {
+	Personality string
+	Type        string
+	Exit        exitcode.Info
+	Parsed      *cmdline.Command // Optional; fills in pack template variables
+	Similar     []string         // Known commands near a name that wasn't found
+	Language    string           // Language code; empty means English
+	Attempts    int              // Failures a command that succeeded put an end to
+	Stuck       time.Duration    // Time those failures took
+	Duration    time.Duration    // How long the command ran; zero if unknown
+	Slow        bool             // A success being remarked on for how long it took
+}
+
+// Engine picks fallback lines for a personality, command type and exit class,
+// avoiding lines it has shown recently.
+type Engine struct {
+	rng         *rand.Rand
+	historyPath string
+	history     []string
+	packLines   []packs.Line
+	style       string
+}
+
+// HistoryPath is where the recently shown lines are persisted.
+func HistoryPath() string {
+	return filepath.Join(config.StateDir(), "fallback_history")
+}
+
+// New creates an engine that persists its history at historyPath. An empty
+// path keeps history in memory only.
+func New(historyPath string) *Engine {
+	return NewSeeded(historyPath, time.Now().UnixNano())
+}
+
+// Default creates an engine with persisted history and the given pack lines,
+// which callers load once so a single run doesn't read the packs twice.
+func Default(style string, lines []packs.Line) *Engine {
+	return New(HistoryPath()).WithPacks(lines).WithStyle(style)
+}
+
+// NewSeeded creates an engine with a fixed random seed.
+func NewSeeded(historyPath string, seed int64) *Engine {
+	engine := &Engine{
+		rng:         rand.New(rand.NewSource(seed)),
+		historyPath: historyPath,
+	}
+	engine.loadHistory()
+	return engine
+}
+
+// WithPacks adds pack lines to the candidates.
+func (e *Engine) WithPacks(lines []packs.Line) *Engine {
+	e.packLines = append(e.packLines, lines...)
+	return e
+}
+
+// WithStyle selects lines, grammar or mixed responses. Unknown styles use lines.
+func (e *Engine) WithStyle(style string) *Engine {
+	e.style = style
+	return e
+}
+
+// Respond produces a line in the engine's style and records it in the history.
+// The grammars are English and about failures, so other languages and
+// successes always pick fixed lines.
+func (e *Engine) Respond(s Situation) string {
+	if !english(s.Language) || s.Exit.Class == exitcode.ClassSuccess {
+		return e.Pick(s)
+	}
+	switch e.style {
+	case StyleGrammar:
+		return e.Invent(s)
+	case StyleMixed:
+		if e.rng.Intn(2) == 0 {
+			return e.Invent(s)
+		}
+	}
+	return e.Pick(s)
+}
+
+// Invent generates a line from the grammar, retrying a few times to avoid
+// lines shown recently, and records it in the history.
+func (e *Engine) Invent(s Situation) string {
+	var line string
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		line = e.Generate(s)
+		if !e.shownWithin(line, len(e.history)) {
+			break
+		}
+	}
+	e.remember(line)
+	return line
+}
+
+// Pick chooses one of the fixed lines and records it in the history.
+func (e *Engine) Pick(s Situation) string {
+	vars := templateVars(s)
+	candidates := Candidates(s.Language, s.Personality, s.Type, s.Exit.Class)
+	if s.Slow {
+		candidates = corpusFor(s.Language, s.Personality)[slowKey]
+	}
+	for _, line := range e.packLines {
+		// Packs are written in English, and only lines meant for a success
+		// congratulate one. Packs have no lines about the wait.
+		if !english(s.Language) || !line.Matches(s.Personality, s.Type, s.Exit) {
+			continue
+		}
+		if s.Slow || (s.Exit.Class == exitcode.ClassSuccess && !line.ExitSpecific()) {
+			continue
+		}
+		candidate := Line{Text: line.Text, Weight: weight(Line{Weight: line.Weight})}
+		if line.ExitSpecific() {
+			candidate.Weight *= 3
+		}
+		candidates = append(candidates, candidate)
+	}
+	candidates = usable(candidates, vars)
+
+	// Pointing out the command they meant beats any generic line
+	if vars["suggestion"] != "" {
+		if suggesting := using(candidates, "suggestion"); len(suggesting) > 0 {
+			candidates = suggesting
+		}
+	}
+
+	// Prefer lines that haven't been shown recently. Once everything has, only
+	// the last half of the candidates is ruled out, so the very latest line
+	// never repeats back to back.
+	for window := len(e.history); window > 0; window = min(window-1, len(candidates)/2) {
+		fresh := make([]Line, 0, len(candidates))
+		for _, line := range candidates {
+			if !e.shownWithin(line.Text, window) {
+				fresh = append(fresh, line)
+			}
+		}
+		if len(fresh) > 0 {
+			candidates = fresh
+			break
+		}
+	}
+
+	line := e.weightedChoice(candidates)
+	e.remember(line)
+	return packs.Render(line, vars)
+}
+
+// usable drops lines that need template variables the failure doesn't have
+func usable(lines []Line, vars map[string]string) []Line {
+	var kept []Line
+	for _, line := range lines {
+		if hasVars(line.Text, vars) {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// using returns the lines that use a template variable
+func using(lines []Line, variable string) []Line {
+	var kept []Line
+	for _, line := range lines {
+		if strings.Contains(line.Text, "{"+variable+"}") {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// templateVars describes the failure for pack lines such as "{executable} again?"
+func templateVars(s Situation) map[string]string {
+	vars := map[string]string{
+		"exit_code": fmt.Sprint(s.Exit.Code),
+		"signal":    s.Exit.Signal,
+		"meaning":   s.Exit.Meaning,
+		"type":      s.Type,
+	}
+	if s.Parsed != nil && len(s.Parsed.Segments) > 0 {
+		segment := s.Parsed.FailedSegment()
+		vars["command"] = truncate(strings.TrimSpace(segment.Raw), 40)
+		vars["executable"] = segment.Executable()
+		vars["subcommand"] = segment.Subcommand()
+	}
+	if s.Attempts > 0 {
+		vars["attempts"] = fmt.Sprint(s.Attempts)
+	}
+	if s.Stuck >= time.Minute {
+		vars["stuck"] = roughly(s.Stuck)
+	}
+	if s.Duration >= time.Minute {
+		vars["duration"] = roughly(s.Duration)
+	}
+	if len(s.Similar) > 0 {
+		vars["suggestion"] = "`" + strings.Join(s.Similar, "` or `") + "`"
+	}
+	return vars
+}
+
+// roughly renders a duration in whole minutes, e.g. "12m" or "1h5m"
+func roughly(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func truncate(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit-3] + "..."
+}
+
+// Candidates returns the weighted lines that apply to a failure. Lines for the
+// most specific command type with a corpus are combined with lines for the
+// exit class, which are weighted up because they say more about what happened.
+func Candidates(language, personality, commandType string, exitClass exitcode.Class) []Line {
+	corpus := corpusFor(language, personality)
+
+	// A success only gets the lines written for one
+	if exitClass == exitcode.ClassSuccess {
+		return corpus[exitKey(exitClass)]
+	}
+
+	var candidates []Line
+	for _, t := range classify.Lineage(commandType) {
+		if lines, exists := corpus[t]; exists {
+			candidates = append(candidates, lines...)
+			break
+		}
+	}
+
+	if lines, exists := corpus[exitKey(exitClass)]; exists {
+		for _, line := range lines {
+			line.Weight = weight(line) * 3
+			candidates = append(candidates, line)
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = corpus[classify.Generic]
+	}
+	return candidates
+}
+
+// corpusFor returns the lines of a personality in a language, falling back to
+// English for languages without a corpus
+func corpusFor(language, personality string) map[string][]Line {
+	if localized, exists := LocalizedCorpus[language]; exists {
+		if corpus, exists := localized[personality]; exists {
+			return corpus
+		}
+		return localized["sarcastic"]
+	}
+	if corpus, exists := Corpus[personality]; exists {
+		return corpus
+	}
+	return Corpus["sarcastic"]
+}
+
+func english(language string) bool {
+	return LocalizedCorpus[language] == nil
+}
+
+func exitKey(class exitcode.Class) string {
+	return "exit." + string(class)
+}
+
+func weight(line Line) int {
+	if line.Weight <= 0 {
+		return 1
+	}
+	return line.Weight
+}
+
+func (e *Engine) weightedChoice(lines []Line) string {
+	total := 0
+	for _, line := range lines {
+		total += weight(line)
+	}
+
+	n := e.rng.Intn(total)
+	for _, line := range lines {
+		n -= weight(line)
+		if n < 0 {
+			return line.Text
+		}
+	}
+	return lines[len(lines)-1].Text
+}
+
+// shownWithin reports whether text is among the last window lines shown
+func (e *Engine) shownWithin(text string, window int) bool {
+	for _, shown := range e.history[len(e.history)-min(window, len(e.history)):] {
+		if shown == text {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) loadHistory() {
+	if e.historyPath == "" {
+		return
+	}
+	content, err := os.ReadFile(e.historyPath)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if line != "" {
+			e.history = append(e.history, line)
+		}
+	}
+}
+
+// remember appends a line to the history, persisting it best-effort
+func (e *Engine) remember(text string) {
+	e.history = append(e.history, text)
+	if len(e.history) > historySize {
+		e.history = e.history[len(e.history)-historySize:]
+	}
+
+	if e.historyPath == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(e.historyPath), 0700); err != nil {
+		return
+	}
+
+	// Write to a temp file and rename so concurrent shells never see a torn file
+	tmp := fmt.Sprintf("%s.%d.tmp", e.historyPath, os.Getpid())
+	if err := os.WriteFile(tmp, []byte(strings.Join(e.history, "\n")+"\n"), 0600); err != nil {
+		return
+	}
+	os.Rename(tmp, e.historyPath)
+}
@@ -0,0 +1,283 @@
+package fallback
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"parrot/internal/classify"
+	"parrot/internal/packs"
+)
+
+// maxDepth bounds symbol expansion so a cyclic grammar can't hang the shell
+const maxDepth = 8
+
+// startSymbol is where generation begins
+const startSymbol = "roast"
+
+var symbolPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// Generate builds a fresh line from the personality's grammar. Symbols such
+// as "{punchline}" expand to one of their productions, preferring variants
+// for the command type ("punchline.git") or exit class ("exit_joke.killed").
+// Template variables like "{executable}" are filled in from the failure, and
+// productions whose variables are unknown are skipped.
+func (e *Engine) Generate(s Situation) string {
+	vars := templateVars(s)
+	line := e.expand(startSymbol, grammarFor(s.Personality), s, vars, 0)
+	return sentenceCase(strings.Join(strings.Fields(line), " "))
+}
+
+func (e *Engine) expand(symbol string, grammar map[string][]Line, s Situation, vars map[string]string, depth int) string {
+	usable := productions(grammar, symbol, s, vars)
+	if len(usable) == 0 {
+		return ""
+	}
+
+	chosen := e.weightedChoice(usable)
+	return symbolPattern.ReplaceAllStringFunc(chosen, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if value, isVar := vars[name]; isVar {
+			return value
+		}
+		if depth < maxDepth {
+			return e.expand(name, grammar, s, vars, depth+1)
+		}
+		return ""
+	})
+}
+
+// productions returns the usable productions of the most specific variant of
+// a symbol: by command type (walking up to parents), then by exit class, then
+// the plain symbol
+func productions(grammar map[string][]Line, symbol string, s Situation, vars map[string]string) []Line {
+	var keys []string
+	for _, t := range classify.Lineage(s.Type) {
+		if t != classify.Generic {
+			keys = append(keys, symbol+"."+t)
+		}
+	}
+	keys = append(keys, symbol+"."+string(s.Exit.Class), symbol)
+
+	for _, key := range keys {
+		lines, exists := grammar[key]
+		if !exists {
+			lines = sharedGrammar[key]
+		}
+
+		var usable []Line
+		for _, line := range lines {
+			if hasVars(line.Text, vars) {
+				usable = append(usable, line)
+			}
+		}
+		if len(usable) > 0 {
+			return usable
+		}
+	}
+	return nil
+}
+
+// hasVars reports whether every template variable the text uses has a value
+func hasVars(text string, vars map[string]string) bool {
+	for _, match := range symbolPattern.FindAllStringSubmatch(text, -1) {
+		if isPlaceholder(match[1]) && vars[match[1]] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isPlaceholder(name string) bool {
+	for _, placeholder := range packs.Placeholders {
+		if name == placeholder {
+			return true
+		}
+	}
+	return false
+}
+
+// sentenceCase capitalises the start of each sentence
+func sentenceCase(text string) string {
+	runes := []rune(text)
+	capitalize := true
+	for i, r := range runes {
+		switch {
+		case capitalize && unicode.IsLetter(r):
+			runes[i] = unicode.ToUpper(r)
+			capitalize = false
+		case capitalize && r == '`':
+			capitalize = false
+		case r == '.' || r == '!' || r == '?':
+			capitalize = i+1 < len(runes) && runes[i+1] == ' '
+		}
+	}
+	return string(runes)
+}
+
+func grammarFor(personality string) map[string][]Line {
+	if grammar, exists := Grammars[personality]; exists {
+		return grammar
+	}
+	return Grammars["sarcastic"]
+}
+
+// sharedGrammar holds symbols every personality can use.
+var sharedGrammar = map[string][]Line{
+	"subject": {
+		{Text: "`{executable} {subcommand}`", Weight: 3},
+		{Text: "`{executable}`", Weight: 2},
+		{Text: "your {executable} command", Weight: 2},
+		{Text: "that {executable} invocation"},
+		{Text: "that command"},
+		{Text: "your latest attempt"},
+	},
+}
+
+// Grammars holds a grammar per personality.
+var Grammars = map[string]map[string][]Line{
+	"mild": {
+		"roast": {
+			{Text: "{opener} {subject} {failed}. {punchline}", Weight: 3},
+			{Text: "{subject} {failed}. {exit_joke}", Weight: 2},
+		},
+		"roast.not_found": {
+			{Text: "{opener} `{executable}` isn't a command. Did you mean {suggestion}?"},
+			{Text: "`{executable}` {failed}. {suggestion} might work better."},
+		},
+		"opener": {
+			{Text: "Hmm,"}, {Text: "Oops,"}, {Text: "Ah,"}, {Text: "Looks like"},
+		},
+		"failed": {
+			{Text: "didn't work out"}, {Text: "hit a snag"}, {Text: "stumbled"}, {Text: "didn't go as planned"},
+		},
+		"punchline": {
+			{Text: "Maybe check the docs?"},
+			{Text: "The --help output might help."},
+			{Text: "Happens to everyone."},
+			{Text: "Worth another look at the arguments."},
+		},
+		"punchline.git":     {{Text: "Maybe check git status?"}, {Text: "A git pull first might help."}},
+		"punchline.nodejs":  {{Text: "Maybe clear the npm cache?"}, {Text: "Check your package.json?"}},
+		"punchline.docker":  {{Text: "Is the Docker daemon running?"}, {Text: "Check your Dockerfile?"}},
+		"punchline.http":    {{Text: "Is the server up?"}, {Text: "Double-check the URL?"}},
+		"punchline.kubectl": {{Text: "Check your current context?"}, {Text: "Is the namespace right?"}},
+		"punchline.python":  {{Text: "Is the right virtualenv active?"}},
+		"punchline.go":      {{Text: "Maybe a go mod tidy?"}},
+		"punchline.cargo":   {{Text: "The compiler message is usually right."}},
+		"exit_joke": {
+			{Text: "Exit code {exit_code}, for the record."},
+		},
+		"exit_joke.not_found":   {{Text: "Is it installed, or just a typo?"}},
+		"exit_joke.permission":  {{Text: "Might need sudo."}},
+		"exit_joke.interrupted": {{Text: "No rush."}},
+		"exit_joke.killed":      {{Text: "It may have run out of memory."}},
+		"exit_joke.crash":       {{Text: "That was a {signal}, so it's not just you."}},
+		"exit_joke.timeout":     {{Text: "The other end might just be slow."}},
+		"exit_joke.network":     {{Text: "Is the network up?"}},
+	},
+
+	"sarcastic": {
+		"roast": {
+			{Text: "{opener} {subject} {failed}. {punchline}", Weight: 3},
+			{Text: "{subject} {failed}. {exit_joke}", Weight: 2},
+			{Text: "{opener} {exit_joke} {punchline}"},
+		},
+		"roast.not_found": {
+			{Text: "{opener} `{executable}`? Did you mean {suggestion}, genius?"},
+			{Text: "`{executable}` {failed}. It's spelled {suggestion}. {punchline}"},
+		},
+		"opener": {
+			{Text: "Wow."}, {Text: "Bravo."}, {Text: "Impressive."}, {Text: "Ah yes,"}, {Text: "Incredible."}, {Text: "Stunning work:"},
+		},
+		"failed": {
+			{Text: "failed spectacularly"},
+			{Text: "fell flat on its face"},
+			{Text: "face-planted"},
+			{Text: "gave up on you"},
+			{Text: "crashed and burned"},
+			{Text: "went exactly as expected"},
+		},
+		"punchline": {
+			{Text: "Truly a visionary."},
+			{Text: "Read the manual? Never heard of it."},
+			{Text: "Groundbreaking stuff."},
+			{Text: "Nobody saw that coming."},
+			{Text: "Have you tried turning your brain off and on again?"},
+		},
+		"punchline.git": {
+			{Text: "Maybe pull first, genius."},
+			{Text: "Git blame is going to love this."},
+			{Text: "Force push it, what could go wrong?"},
+		},
+		"punchline.git.push":  {{Text: "The remote has standards."}, {Text: "Maybe pull first, genius."}},
+		"punchline.nodejs":    {{Text: "Even node_modules is judging you."}, {Text: "Another 400MB of dependencies, wasted."}},
+		"punchline.docker":    {{Text: "Works on your machine, though, right?"}, {Text: "Containers can't contain this."}},
+		"punchline.http":      {{Text: "The server is ignoring you on purpose."}, {Text: "404: competence not found."}},
+		"punchline.kubectl":   {{Text: "The cluster would like a word."}, {Text: "Wrong context again?"}},
+		"punchline.terraform": {{Text: "Plan: 0 to add, 1 to embarrass."}},
+		"punchline.python":    {{Text: "Have you tried a different virtualenv? Or career?"}},
+		"punchline.go":        {{Text: "Go says: if err != nil { blame(you) }"}},
+		"punchline.cargo":     {{Text: "The borrow checker sends its regards."}},
+		"punchline.make":      {{Text: "Make it make sense."}},
+		"punchline.ssh":       {{Text: "The server pretended not to be home."}},
+		"exit_joke": {
+			{Text: "Exit code {exit_code}: a classic."},
+			{Text: "Exit code {exit_code}, as is tradition."},
+		},
+		"exit_joke.not_found":      {{Text: "Command not found, like your attention to detail."}, {Text: "Typing is hard, I know."}},
+		"exit_joke.not_executable": {{Text: "chmod +x exists, you know."}},
+		"exit_joke.permission":     {{Text: "Permission denied. The computer has boundaries."}},
+		"exit_joke.interrupted":    {{Text: "Ctrl-C: the universal sign of giving up."}},
+		"exit_joke.killed":         {{Text: "Even the OOM killer had enough."}},
+		"exit_joke.crash":          {{Text: "{signal}! Memory safety is a lifestyle choice."}},
+		"exit_joke.timeout":        {{Text: "It timed out waiting for you to get it right."}},
+		"exit_joke.network":        {{Text: "The network doesn't want to talk to you either."}},
+		"exit_joke.usage":          {{Text: "Those flags aren't real. --help is."}},
+		"exit_joke.broken_pipe":    {{Text: "Broken pipe. Call a plumber."}},
+		"exit_joke.terminated":     {{Text: "Terminated. Fitting."}},
+	},
+
+	"savage": {
+		"roast": {
+			{Text: "{opener} {subject} {failed}. {punchline}", Weight: 3},
+			{Text: "{subject} {failed}. {exit_joke}", Weight: 2},
+			{Text: "{opener} {exit_joke} {punchline}"},
+		},
+		"roast.not_found": {
+			{Text: "{opener} `{executable}` isn't a command. {suggestion} is. {punchline}"},
+			{Text: "It's {suggestion}, not `{executable}`. {punchline}"},
+		},
+		"opener": {
+			{Text: "Pathetic."}, {Text: "Unbelievable."}, {Text: "Of course."}, {Text: "Naturally,"}, {Text: "Look at this:"},
+		},
+		"failed": {
+			{Text: "died a well-deserved death"},
+			{Text: "failed harder than your last code review"},
+			{Text: "collapsed under the weight of your incompetence"},
+			{Text: "gave up, and honestly, same"},
+		},
+		"punchline": {
+			{Text: "Consider a career in management."},
+			{Text: "Your keyboard deserves better."},
+			{Text: "This is why we can't have nice things."},
+			{Text: "Even the error message is embarrassed."},
+		},
+		"punchline.git":     {{Text: "Your commit history is a crime scene."}, {Text: "Git remembers everything. Unfortunately."}},
+		"punchline.nodejs":  {{Text: "Even left-pad had more going for it."}},
+		"punchline.docker":  {{Text: "Your containers are as stable as your decisions."}},
+		"punchline.http":    {{Text: "The internet has collectively blocked you."}},
+		"punchline.kubectl": {{Text: "Your pods are crash-looping in shame."}},
+		"exit_joke": {
+			{Text: "Exit code {exit_code}, roughly your number of good ideas today."},
+		},
+		"exit_joke.not_found":   {{Text: "Command not found. Neither is your competence."}},
+		"exit_joke.permission":  {{Text: "Permission denied. The system knows who you are."}},
+		"exit_joke.interrupted": {{Text: "Ctrl-C. Quitting is what you do best."}},
+		"exit_joke.killed":      {{Text: "The kernel killed it. Mercy, really."}},
+		"exit_joke.crash":       {{Text: "{signal}. Your code touched memory it had no business touching."}},
+		"exit_joke.timeout":     {{Text: "Nothing wants to wait for you."}},
+		"exit_joke.network":     {{Text: "The network would rather be down than talk to you."}},
+		"exit_joke.usage":       {{Text: "You can't even pass arguments correctly."}},
+	},
+}
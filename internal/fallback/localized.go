@@ -0,0 +1,271 @@
+package fallback
+
+// LocalizedCorpus holds built-in lines in languages other than English, by
+// language code, then personality, then command type or exit class like
+// Corpus. It is smaller than the English corpus: anything it lacks falls back
+// to the generic lines of the same language.
+var LocalizedCorpus = map[string]map[string]map[string][]Line{
+	"de": {
+		"mild": {
+			"git": {
+				{Text: "Git hat nicht mitgespielt. Vielleicht zuerst git pull?"},
+				{Text: "Hoppla, das ging schief. Ein Blick auf git status hilft."},
+			},
+			"generic": {
+				{Text: "Das hat nicht geklappt. Stimmt die Syntax?"},
+				{Text: "Etwas ist schiefgelaufen. Vielleicht hilft --help?"},
+				{Text: "Fehler entdeckt. Die Parameter noch einmal prüfen?"},
+			},
+			"exit.not_found": {
+				{Text: "Befehl nicht gefunden. Ein Tippfehler vielleicht?"},
+				{Text: "Meintest du {suggestion}?"},
+			},
+			"exit.permission": {
+				{Text: "Zugriff verweigert. Braucht das sudo?"},
+			},
+			"exit.interrupted": {
+				{Text: "Abgebrochen. Kein Problem, einfach nochmal versuchen."},
+			},
+			"exit.success": {
+				{Text: "Es läuft! Aller guten Dinge sind drei."},
+				{Text: "Geschafft, nach nur {attempts} Versuchen."},
+			},
+			"slow": {
+				{Text: "Fertig! Das hat aber gedauert."},
+				{Text: "Geschafft, nach {duration}. Hoffentlich gab es Kaffee."},
+			},
+		},
+		"sarcastic": {
+			"git": {
+				{Text: "Wieder nicht gepullt? Ein Klassiker.", Weight: 2},
+				{Text: "Deine Commits sind so kaputt wie dein Workflow."},
+			},
+			"generic": {
+				{Text: "Überraschung! Es hat nicht funktioniert.", Weight: 2},
+				{Text: "Toller Befehl. Schade nur, dass er nicht geht."},
+				{Text: "Die Fehlermeldung liest sich von allein, versprochen."},
+			},
+			"exit.not_found": {
+				{Text: "Befehl nicht gefunden. Tippen ist schwer, ich weiß."},
+				{Text: "Meintest du {suggestion}, du Genie?"},
+			},
+			"exit.permission": {
+				{Text: "Zugriff verweigert. Der Computer hat Grenzen. Respektier sie."},
+			},
+			"exit.interrupted": {
+				{Text: "Strg-C: das universelle Zeichen fürs Aufgeben."},
+			},
+			"exit.success": {
+				{Text: "Es hat funktioniert! Man rufe die Presse."},
+				{Text: "Nur {attempts} Versuche. Bestimmt ein persönlicher Rekord."},
+			},
+			"slow": {
+				{Text: "Na, das hat ja lange genug gedauert."},
+				{Text: "{duration}. Ich bin beim Warten ein Jahr gealtert."},
+			},
+		},
+		"savage": {
+			"git": {
+				{Text: "Git lehnt deinen Code entschiedener ab als alle anderen dich."},
+			},
+			"generic": {
+				{Text: "Dein Befehl ist härter gescheitert als du am Leben.", Weight: 2},
+				{Text: "Fehler: Die Inkompetenz des Nutzers übersteigt die Systemgrenzen."},
+			},
+			"exit.not_found": {
+				{Text: "Befehl nicht gefunden. Deine Kompetenz auch nicht."},
+				{Text: "Es heißt {suggestion}. Rechtschreibung ist keine Option, sondern Pflicht."},
+			},
+			"exit.permission": {
+				{Text: "Zugriff verweigert. Das System weiß, wer du bist."},
+			},
+			"exit.interrupted": {
+				{Text: "Strg-C. Aufgeben ist das Einzige, was du kannst."},
+			},
+			"exit.success": {
+				{Text: "Glückwunsch zum absoluten Minimum."},
+				{Text: "{attempts} Versuche. Erwarte keine Medaille."},
+			},
+			"slow": {
+				{Text: "Es lief. Irgendwann. Wie deine Karriere."},
+				{Text: "{duration} dafür? Kontinentaldrift ist schneller."},
+			},
+		},
+	},
+
+	"es": {
+		"mild": {
+			"git": {
+				{Text: "Git no colaboró. ¿Quizá un git pull primero?"},
+				{Text: "Vaya, no funcionó. Revisa git status."},
+			},
+			"generic": {
+				{Text: "El comando no funcionó. ¿Revisamos la sintaxis?"},
+				{Text: "Algo salió mal. ¿Probamos con --help?"},
+				{Text: "Error detectado. ¿Comprobamos los parámetros?"},
+			},
+			"exit.not_found": {
+				{Text: "Comando no encontrado. ¿Una errata, quizá?"},
+				{Text: "¿Querías decir {suggestion}?"},
+			},
+			"exit.permission": {
+				{Text: "Permiso denegado. ¿Hace falta sudo?"},
+			},
+			"exit.interrupted": {
+				{Text: "Interrumpido. Sin problema, inténtalo otra vez."},
+			},
+			"exit.success": {
+				{Text: "¡Funciona! A la tercera va la vencida."},
+				{Text: "Lo lograste, solo en {attempts} intentos."},
+			},
+			"slow": {
+				{Text: "¡Listo! Aunque tardó bastante."},
+				{Text: "Funcionó tras {duration}. Espero que hubiera café."},
+			},
+		},
+		"sarcastic": {
+			"git": {
+				{Text: "¿Otra vez sin hacer pull? Todo un clásico.", Weight: 2},
+				{Text: "Tus commits están tan rotos como tu flujo de trabajo."},
+			},
+			"generic": {
+				{Text: "¡Sorpresa! No funcionó.", Weight: 2},
+				{Text: "Gran comando. Lástima que no sirva."},
+				{Text: "El mensaje de error se lee solo, te lo prometo."},
+			},
+			"exit.not_found": {
+				{Text: "Comando no encontrado. Escribir es difícil, lo sé."},
+				{Text: "¿Querías decir {suggestion}, genio?"},
+			},
+			"exit.permission": {
+				{Text: "Permiso denegado. El ordenador tiene límites. Respétalos."},
+			},
+			"exit.interrupted": {
+				{Text: "Ctrl-C: el gesto universal de rendirse."},
+			},
+			"exit.success": {
+				{Text: "¡Funcionó! Que alguien avise a la prensa."},
+				{Text: "Solo {attempts} intentos. Seguro que es tu récord."},
+			},
+			"slow": {
+				{Text: "Vaya, sí que tardó."},
+				{Text: "{duration}. Envejecí un año esperando."},
+			},
+		},
+		"savage": {
+			"git": {
+				{Text: "Git rechaza tu código con más ganas que todo el mundo a ti."},
+			},
+			"generic": {
+				{Text: "Tu comando fracasó más fuerte que tú en la vida.", Weight: 2},
+				{Text: "Error: la incompetencia del usuario supera los límites del sistema."},
+			},
+			"exit.not_found": {
+				{Text: "Comando no encontrado. Tu talento tampoco."},
+				{Text: "Es {suggestion}. La ortografía no es opcional."},
+			},
+			"exit.permission": {
+				{Text: "Permiso denegado. El sistema sabe quién eres."},
+			},
+			"exit.interrupted": {
+				{Text: "Ctrl-C. Rendirte es lo único que se te da bien."},
+			},
+			"exit.success": {
+				{Text: "Enhorabuena por hacer lo mínimo, por fin."},
+				{Text: "{attempts} intentos. No esperes una medalla."},
+			},
+			"slow": {
+				{Text: "Funcionó. Al final. Como tu carrera."},
+				{Text: "¿{duration} para eso? La deriva continental es más rápida."},
+			},
+		},
+	},
+
+	"ja": {
+		"mild": {
+			"git": {
+				{Text: "Git がうまくいきませんでした。先に git pull してみては？"},
+				{Text: "おっと、失敗です。git status を確認してみましょう。"},
+			},
+			"generic": {
+				{Text: "うまくいきませんでした。構文を確認してみては？"},
+				{Text: "何かがおかしいようです。--help を試してみましょう。"},
+				{Text: "エラーです。引数をもう一度確認してみては？"},
+			},
+			"exit.not_found": {
+				{Text: "コマンドが見つかりません。打ち間違いかも？"},
+				{Text: "もしかして {suggestion} ですか？"},
+			},
+			"exit.permission": {
+				{Text: "権限がありません。sudo が必要かも？"},
+			},
+			"exit.interrupted": {
+				{Text: "中断しました。大丈夫、もう一度どうぞ。"},
+			},
+			"exit.success": {
+				{Text: "動いた！三度目の正直ですね。"},
+				{Text: "{attempts}回目で成功。よく頑張りました。"},
+			},
+			"slow": {
+				{Text: "完了！でも時間がかかりましたね。"},
+				{Text: "{duration}かかって成功。コーヒーは飲めましたか？"},
+			},
+		},
+		"sarcastic": {
+			"git": {
+				{Text: "また pull し忘れ？お約束ですね。", Weight: 2},
+				{Text: "あなたのコミットはワークフローと同じくらい壊れています。"},
+			},
+			"generic": {
+				{Text: "なんと！失敗しました。誰も予想できませんでしたね。", Weight: 2},
+				{Text: "素晴らしいコマンドですね。動かない点を除けば。"},
+				{Text: "エラーメッセージは読むためにあるんですよ。"},
+			},
+			"exit.not_found": {
+				{Text: "コマンドが見つかりません。タイピングって難しいですよね。"},
+				{Text: "{suggestion} のことですよね、天才さん？"},
+			},
+			"exit.permission": {
+				{Text: "権限がありません。コンピューターにも境界線があるんです。"},
+			},
+			"exit.interrupted": {
+				{Text: "Ctrl-C：諦めの万国共通サイン。"},
+			},
+			"exit.success": {
+				{Text: "動いた！記者会見を開きましょう。"},
+				{Text: "たった{attempts}回で成功。自己ベストでしょうね。"},
+			},
+			"slow": {
+				{Text: "いやあ、ずいぶん待たされました。"},
+				{Text: "{duration}。待っている間に一歳老けました。"},
+			},
+		},
+		"savage": {
+			"git": {
+				{Text: "Git はみんながあなたを拒むより強くあなたのコードを拒んでいます。"},
+			},
+			"generic": {
+				{Text: "このコマンドは、あなたの人生以上に盛大に失敗しました。", Weight: 2},
+				{Text: "エラー：ユーザーの無能さがシステムの限界を超えました。"},
+			},
+			"exit.not_found": {
+				{Text: "コマンドが見つかりません。あなたの実力も。"},
+				{Text: "正しくは {suggestion}。スペルは任意ではありません。"},
+			},
+			"exit.permission": {
+				{Text: "権限がありません。システムはあなたが誰か知っています。"},
+			},
+			"exit.interrupted": {
+				{Text: "Ctrl-C。諦めることだけは得意ですね。"},
+			},
+			"exit.success": {
+				{Text: "やっと最低限できましたね。おめでとう。"},
+				{Text: "{attempts}回も失敗して今さら成功。メダルはありません。"},
+			},
+			"slow": {
+				{Text: "動いた。やっと。あなたのキャリアみたいに。"},
+				{Text: "{duration}もかけてそれ？大陸移動の方が速い。"},
+			},
+		},
+	},
+}
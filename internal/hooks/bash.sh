@@ -0,0 +1,94 @@
+# Bash has no preexec hook. The DEBUG trap fires before every simple command,
+# so only the first one after the prompt counts as the start of a command line.
+PARROT_AT_PROMPT=""
+
+# Called at the end of PROMPT_COMMAND: the next DEBUG trap starts a new command.
+# HISTCMD is the number the next history entry will get.
+parrot_prompt_ready() {
+    PARROT_AT_PROMPT=1
+    PARROT_NEXT_HISTORY="${HISTCMD:-}"
+}
+
+# DEBUG trap: pass the command line to parrot_preexec once per prompt
+parrot_debug_trap() {
+    [ -n "$PARROT_AT_PROMPT" ] || return 0
+    # Completion functions and key bindings run at the prompt too
+    [ -n "${COMP_LINE:-}" ] || [ -n "${READLINE_LINE+set}" ] && return 0
+    PARROT_AT_PROMPT=""
+    # Enter on an empty line goes straight to PROMPT_COMMAND, which starts
+    # with parrot_precmd
+    [ "$BASH_COMMAND" = parrot_precmd ] || [ "$BASH_COMMAND" = parrot_prompt_ready ] && return 0
+    local line
+    if line=$(parrot_history_line); then
+        parrot_preexec "$line"
+    elif ! parrot_prompt_command "$BASH_COMMAND"; then
+        parrot_preexec "$BASH_COMMAND"
+    fi
+    return 0
+}
+
+# The command line about to run, if it made it into history, multi-line
+# commands included. Lines kept out of it by HISTCONTROL, HISTIGNORE or
+# set +o history only have $BASH_COMMAND, the first simple command.
+parrot_history_line() {
+    local entry
+    entry=$(HISTTIMEFORMAT= builtin history 1 2>/dev/null)
+    [[ "$entry" =~ ^[[:space:]]*([0-9]+)[*]?[[:space:]]+(.*)$ ]] &&
+        [ "${BASH_REMATCH[1]}" = "$PARROT_NEXT_HISTORY" ] || return 1
+    printf '%s' "${BASH_REMATCH[2]}"
+}
+
+# Whether a command that isn't in history is one of PROMPT_COMMAND's, put
+# before parrot_precmd after parrot was loaded, rather than the user's
+parrot_prompt_command() {
+    local IFS=$'\n;' commands command
+    read -r -d '' -a commands <<< "${PROMPT_COMMAND[*]}"
+    for command in "${commands[@]}"; do
+        command="${command#"${command%%[![:space:]]*}"}"
+        command="${command%"${command##*[![:space:]]}"}"
+        [ "$command" = "$1" ] && return 0
+    done
+    return 1
+}
+
+# Bash setup
+if [ -n "${bash_preexec_imported:-}${__bp_imported:-}" ]; then
+    # bash-preexec is loaded: use its hooks rather than fighting over the trap
+    [[ " ${preexec_functions[*]} " == *" parrot_preexec "* ]] || preexec_functions+=(parrot_preexec)
+    [[ " ${precmd_functions[*]} " == *" parrot_precmd "* ]] || precmd_functions+=(parrot_precmd)
+elif [[ "${PROMPT_COMMAND[*]}" != *parrot_precmd* ]]; then
+    # parrot_precmd goes first to see the command's $?, parrot_prompt_ready last
+    # so other prompt commands aren't taken for the user's
+    if [[ "$(declare -p PROMPT_COMMAND 2>/dev/null)" == "declare -a"* ]]; then
+        PROMPT_COMMAND=(parrot_precmd "${PROMPT_COMMAND[@]}" parrot_prompt_ready)
+    else
+        PROMPT_COMMAND="parrot_precmd"$'\n'"${PROMPT_COMMAND:-}"$'\n'"parrot_prompt_ready"
+    fi
+    # Run after any DEBUG trap that is already set; trap -p quotes it
+    parrot_previous_trap="$(trap -p DEBUG)"
+    parrot_previous_trap="${parrot_previous_trap#trap -- }"
+    eval "parrot_previous_trap=${parrot_previous_trap% DEBUG}"
+    if [ -n "$parrot_previous_trap" ]; then
+        trap -- "$parrot_previous_trap"$'\n''parrot_debug_trap' DEBUG
+    else
+        trap 'parrot_debug_trap' DEBUG
+    fi
+    unset parrot_previous_trap
+fi
+if [ "${PARROT_NOT_FOUND_HANDLER:-}" = "true" ]; then
+    if declare -F command_not_found_handle >/dev/null &&
+        ! declare -f command_not_found_handle | grep -q parrot_not_found; then
+        eval "parrot_previous_not_found() $(declare -f command_not_found_handle | tail -n +2)"
+    fi
+    command_not_found_handle() { parrot_not_found "$@"; }
+fi
+# Optional fix key, in readline notation, e.g. PARROT_FIX_KEY='\C-x\C-f'
+if [ -n "${PARROT_FIX_KEY:-}" ] && [[ $- == *i* ]]; then
+    bind -x "\"$PARROT_FIX_KEY\": parrot_fix_widget"
+fi
+echo "🦜 Parrot is now watching your bash commands..."
+
+# Show performance tip
+if [ "${PARROT_ASYNC:-}" != "true" ]; then
+    echo "💡 Tip: Set PARROT_ASYNC=true to prevent terminal hangs on slow networks"
+fi
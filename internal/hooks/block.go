@@ -0,0 +1,127 @@
+package hooks
+
+import "strings"
+
+// Lines parrot adds to a startup file are fenced, so it can find, update and
+// remove them without touching anything else. Every supported shell reads
+// # as a comment.
+const (
+	blockStart = "# >>> parrot >>>"
+	blockEnd   = "# <<< parrot <<<"
+	blockNote  = "# Managed by 'parrot install'; 'parrot uninstall' removes it."
+)
+
+// legacyHeader starts the unfenced block older versions appended
+const legacyHeader = "# Parrot CLI hooks and configuration"
+
+// Fence wraps startup file lines in the markers that identify parrot's block.
+func Fence(body string) string {
+	return blockStart + "\n" + blockNote + "\n" + strings.Trim(body, "\n") + "\n" + blockEnd + "\n"
+}
+
+// HasBlock reports whether a startup file has a parrot block, fenced or left
+// by an older version.
+func HasBlock(content string) bool {
+	return len(findBlocks(lines(content))) > 0
+}
+
+// Upsert puts block in place of the first parrot block in a startup file's
+// content, dropping any others, or appends it after a blank line if there is
+// none.
+func Upsert(content, block string) string {
+	all := lines(content)
+	blocks := findBlocks(all)
+	if len(blocks) == 0 {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if content != "" {
+			content += "\n"
+		}
+		return content + block
+	}
+
+	var out strings.Builder
+	next := 0
+	for i, span := range blocks {
+		out.WriteString(strings.Join(all[next:span.start], ""))
+		if i == 0 {
+			if strings.TrimSpace(all[span.start]) == "" {
+				out.WriteString(all[span.start])
+			}
+			out.WriteString(block)
+		}
+		next = span.end
+	}
+	out.WriteString(strings.Join(all[next:], ""))
+	return out.String()
+}
+
+// Remove deletes every parrot block from a startup file's content and
+// reports whether there were any.
+func Remove(content string) (string, bool) {
+	all := lines(content)
+	blocks := findBlocks(all)
+	if len(blocks) == 0 {
+		return content, false
+	}
+
+	var out strings.Builder
+	next := 0
+	for _, span := range blocks {
+		out.WriteString(strings.Join(all[next:span.start], ""))
+		next = span.end
+	}
+	out.WriteString(strings.Join(all[next:], ""))
+	return out.String(), true
+}
+
+// span is a range of lines, end exclusive
+type span struct {
+	start, end int
+}
+
+// findBlocks locates parrot's blocks in order, each with the blank line that
+// was added before it. A fenced block runs from its start marker to its end
+// marker; one without an end marker was mangled by hand and is left alone.
+// An unfenced block is the legacy header and the lines after it that mention
+// parrot or OLLAMA_KEEP_ALIVE.
+func findBlocks(all []string) []span {
+	var blocks []span
+	for i := 0; i < len(all); i++ {
+		end := -1
+		switch strings.TrimSpace(all[i]) {
+		case blockStart:
+			for j := i + 1; j < len(all) && end < 0; j++ {
+				if strings.TrimSpace(all[j]) == blockEnd {
+					end = j + 1
+				}
+			}
+		case legacyHeader:
+			end = i + 1
+			for end < len(all) && (strings.Contains(all[end], "parrot") || strings.Contains(all[end], "OLLAMA_KEEP_ALIVE")) {
+				end++
+			}
+		}
+		if end < 0 {
+			continue
+		}
+
+		start := i
+		if start > 0 && strings.TrimSpace(all[start-1]) == "" && (len(blocks) == 0 || blocks[len(blocks)-1].end < start) {
+			start--
+		}
+		blocks = append(blocks, span{start, end})
+		i = end - 1
+	}
+	return blocks
+}
+
+// lines splits content into lines that keep their newlines
+func lines(content string) []string {
+	all := strings.SplitAfter(content, "\n")
+	if all[len(all)-1] == "" {
+		all = all[:len(all)-1]
+	}
+	return all
+}
@@ -0,0 +1,190 @@
+# Session transcript: each command is appended so parrot can see what led up
+# to a failure. Only the user may read it.
+PARROT_SESSION_ID="${PARROT_SESSION_ID:-$$-$(date +%s)}"
+PARROT_SESSION_FILE="${XDG_STATE_HOME:-$HOME/.local/state}/parrot/sessions/${PARROT_SESSION_ID}.tsv"
+export PARROT_SESSION_ID PARROT_SESSION_FILE
+(umask 077 && mkdir -p "$(dirname "$PARROT_SESSION_FILE")") 2>/dev/null
+chmod 700 "$(dirname "$PARROT_SESSION_FILE")" 2>/dev/null
+
+# Left by the command-not-found handler when it has already mocked a failure
+PARROT_NOT_FOUND_MARK="${PARROT_SESSION_FILE%.tsv}.not_found"
+
+# Current time in milliseconds (falls back to whole seconds on older shells)
+parrot_now_ms() {
+    if [ -n "${EPOCHREALTIME:-}" ]; then
+        local secs="${EPOCHREALTIME%[.,]*}" frac="${EPOCHREALTIME#*[.,]}000"
+        echo "$(( secs * 1000 + 10#${frac:0:3} ))"
+    else
+        echo "$(( $(date +%s) * 1000 ))"
+    fi
+}
+
+# Whether a command looks like it carries a secret. parrot init sets
+# PARROT_SECRET_PATTERN, matched ignoring case.
+parrot_secret() {
+    [ -n "${PARROT_SECRET_PATTERN:-}" ] || return 1
+    if [ -n "$BASH_VERSION" ]; then
+        local nocase="" found=1
+        shopt -q nocasematch && nocase=1
+        shopt -s nocasematch
+        [[ $1 =~ $PARROT_SECRET_PATTERN ]] && found=0
+        [ -n "$nocase" ] || shopt -u nocasematch
+        return $found
+    else
+        setopt localoptions nocasematch
+        [[ $1 =~ $PARROT_SECRET_PATTERN ]]
+    fi
+}
+
+# Append a command to the session transcript: time, exit code, duration (ms),
+# working directory, command. Commands that look like they carry a secret are
+# left out.
+parrot_record() {
+    local cmd="$1" exit_code="$2" duration_ms="$3" cwd="${PWD//[$'\t\n']/ }"
+    [ -z "$cmd" ] && return
+    [ "${PARROT_TRANSCRIPT:-true}" = "false" ] && return
+    parrot_secret "$cmd" && return
+    cmd="${cmd//$'\t'/ }"
+    cmd="${cmd//$'\n'/\\n}"
+    [ -e "$PARROT_SESSION_FILE" ] || (umask 077 && : >> "$PARROT_SESSION_FILE") 2>/dev/null
+    printf '%s\t%s\t%s\t%s\t%s\n' "$(date +%s)" "$exit_code" "$duration_ms" "$cwd" "$cmd" >> "$PARROT_SESSION_FILE" 2>/dev/null
+}
+
+# Alias and function names, so parrot can suggest them for unknown commands
+parrot_aliases() {
+    if [ -n "$BASH_VERSION" ]; then
+        { compgen -a; compgen -A function; } 2>/dev/null | grep -v '^_' | tr '\n' ' '
+    else
+        print -r -- ${(k)aliases} ${${(k)functions}:#_*}
+    fi
+}
+
+# Mock a failed command, in the background if PARROT_ASYNC is set and on
+# stderr if PARROT_STDERR is. COLUMNS is passed along so multi-line styles fit
+# the terminal, the status of each pipeline stage so parrot can tell which
+# one failed, and the run time in milliseconds.
+parrot_mock() {
+    local cmd="$1" exit_code="$2" stages="${3:-}" duration_ms="${4:-0}" aliases=""
+    [ "$exit_code" -eq 127 ] && aliases="$(parrot_aliases)"
+    if [ "${PARROT_ASYNC:-}" = "true" ]; then
+        parrot_run_mock "$cmd" "$exit_code" "$aliases" "$stages" "$duration_ms" &
+    else
+        parrot_run_mock "$cmd" "$exit_code" "$aliases" "$stages" "$duration_ms"
+    fi
+}
+
+parrot_run_mock() {
+    if [ "${PARROT_STDERR:-}" = "true" ]; then
+        COLUMNS="${COLUMNS:-}" PARROT_ALIASES="$3" "$PARROT_BIN" mock --pipestatus "$4" --duration "${5}ms" "$1" "$2" >&2
+    else
+        COLUMNS="${COLUMNS:-}" PARROT_ALIASES="$3" "$PARROT_BIN" mock --pipestatus "$4" --duration "${5}ms" "$1" "$2"
+    fi
+}
+
+# Whether a successful command ran long enough that it may have been slow.
+# parrot init sets PARROT_SLOW_AFTER to the shortest configured threshold.
+parrot_ran_long() {
+    [ -n "${PARROT_SLOW_AFTER:-}" ] && [ -n "$1" ] && [ "$1" -ge $(( PARROT_SLOW_AFTER * 1000 )) ]
+}
+
+# Whether a pipeline stage failed even though the pipeline as a whole didn't,
+# as in `cat missing.txt | wc -l` without pipefail. SIGPIPE (141) only means a
+# later stage stopped reading.
+parrot_stage_failed() {
+    local code
+    [ -n "${ZSH_VERSION:-}" ] && setopt localoptions shwordsplit
+    for code in $1; do
+        case "$code" in
+            0|141) ;;
+            *) return 0 ;;
+        esac
+    done
+    return 1
+}
+
+# Whether the command-not-found handler already mocked this prompt's failure
+parrot_not_found_handled() {
+    [ -e "$PARROT_NOT_FOUND_MARK" ] || return 1
+    rm -f "$PARROT_NOT_FOUND_MARK"
+}
+
+# Function to check if parrot binary exists
+parrot_check() {
+    if ! command -v "$PARROT_BIN" &> /dev/null; then
+        echo "⚠️  Parrot binary not found at $PARROT_BIN. Run 'parrot install' again after moving it."
+        return 1
+    fi
+    return 0
+}
+
+# Function called before each command, with the command line
+parrot_preexec() {
+    PARROT_LAST_CMD="$1"
+    PARROT_CMD_START=$(parrot_now_ms)
+}
+
+# Function called before each prompt, with $? and the per-stage statuses
+# (bash-preexec's BP_PIPESTATUS, bash's PIPESTATUS or zsh's pipestatus) still
+# set by the command
+parrot_precmd() {
+    local exit_code=$? stages="${BP_PIPESTATUS[*]:-${PIPESTATUS[*]:-${pipestatus[*]:-}}}"
+    local last_cmd="${PARROT_LAST_CMD:-}" duration_ms=""
+    if [ -n "${PARROT_CMD_START:-}" ]; then
+        duration_ms=$(( $(parrot_now_ms) - PARROT_CMD_START ))
+    fi
+    # Pressing Enter on an empty line runs no command; don't report the old one again
+    PARROT_LAST_CMD=""
+    PARROT_CMD_START=""
+    
+    parrot_record "$last_cmd" "$exit_code" "$duration_ms"
+    local handled=""
+    parrot_not_found_handled && handled=1
+    [ -n "$last_cmd" ] || return
+    
+    # Only mock if the command, or a stage of its pipeline, failed. The
+    # failure is remembered, so the command finally working can be reported
+    # too and earn a backhanded congratulation.
+    if [ $exit_code -ne 0 ] || parrot_stage_failed "$stages"; then
+        PARROT_FAILED_CMD="$last_cmd"
+        [ -z "$handled" ] && parrot_check && parrot_mock "$last_cmd" "$exit_code" "$stages" "$duration_ms"
+    elif [ "$last_cmd" = "${PARROT_FAILED_CMD:-}" ]; then
+        PARROT_FAILED_CMD=""
+        parrot_check && parrot_mock "$last_cmd" 0 "$stages" "$duration_ms"
+    elif parrot_ran_long "$duration_ms"; then
+        # Successes that took forever may get a comment on the wait
+        parrot_check && parrot_mock "$last_cmd" 0 "$stages" "$duration_ms"
+    fi
+}
+
+# Key binding widget: put the suggested fix for the last failure on the
+# command line, where it can be edited and run by the shell itself
+parrot_fix_widget() {
+    local fix
+    fix=$("$PARROT_BIN" fix --print 2>/dev/null) || return
+    if [ -n "$BASH_VERSION" ]; then
+        READLINE_LINE="$fix"
+        READLINE_POINT=${#READLINE_LINE}
+    else
+        BUFFER="$fix"
+        CURSOR=${#BUFFER}
+    fi
+}
+
+# Optional command-not-found handler (PARROT_NOT_FOUND_HANDLER=true): the
+# parrot suggests the command you meant as soon as the shell gives up on one.
+# A handler that was already installed, such as a distribution's package
+# suggestions, still runs first.
+parrot_not_found() {
+    if typeset -f parrot_previous_not_found >/dev/null 2>&1; then
+        parrot_previous_not_found "$@"
+    elif [ -n "$BASH_VERSION" ]; then
+        printf 'bash: %s: command not found\n' "$1" >&2
+    else
+        printf 'zsh: command not found: %s\n' "$1" >&2
+    fi
+    if parrot_check; then
+        : > "$PARROT_NOT_FOUND_MARK"
+        parrot_mock "$*" 127
+    fi
+    return 127
+}
@@ -0,0 +1,146 @@
+# Session transcript: each command is appended so parrot can see what led up
+# to a failure. Only the user may read it.
+if not set -q PARROT_SESSION_ID
+    set -gx PARROT_SESSION_ID $fish_pid-(date +%s)
+end
+set -l parrot_state_home $HOME/.local/state
+if set -q XDG_STATE_HOME; and test -n "$XDG_STATE_HOME"
+    set parrot_state_home $XDG_STATE_HOME
+end
+set -gx PARROT_SESSION_FILE $parrot_state_home/parrot/sessions/$PARROT_SESSION_ID.tsv
+set -l parrot_umask (umask)
+umask 077
+mkdir -p (dirname $PARROT_SESSION_FILE) 2>/dev/null
+umask $parrot_umask
+chmod 700 (dirname $PARROT_SESSION_FILE) 2>/dev/null
+
+# Left by the command-not-found handler when it has already mocked a failure
+set -g PARROT_NOT_FOUND_MARK (string replace -r '\.tsv$' '' -- $PARROT_SESSION_FILE).not_found
+
+# Append a command to the session transcript: time, exit code, duration (ms),
+# working directory, command. Commands that look like they carry a secret
+# (parrot init sets PARROT_SECRET_PATTERN) are left out.
+function parrot_record --argument-names cmd exit_code duration_ms
+    test -z "$cmd"; and return
+    test "$PARROT_TRANSCRIPT" = false; and return
+    if test -n "$PARROT_SECRET_PATTERN"; and string match -qri -- $PARROT_SECRET_PATTERN $cmd
+        return
+    end
+    if not test -e $PARROT_SESSION_FILE
+        touch $PARROT_SESSION_FILE 2>/dev/null; and chmod 600 $PARROT_SESSION_FILE
+    end
+    set cmd (string replace -a \t ' ' -- $cmd | string join '\n')
+    set -l cwd (string replace -a -r '[\t\n]' ' ' -- $PWD)
+    printf '%s\t%s\t%s\t%s\t%s\n' (date +%s) $exit_code "$duration_ms" "$cwd" "$cmd" >>$PARROT_SESSION_FILE 2>/dev/null
+end
+
+# Function names, so parrot can suggest them for unknown commands
+function parrot_aliases
+    functions -n | string match -v -r '^_' | string join ' '
+end
+
+# Mock a failed command, in the background if PARROT_ASYNC is set and on
+# stderr if PARROT_STDERR is. COLUMNS is passed along so multi-line styles fit
+# the terminal, the status of each pipeline stage so parrot can tell which
+# one failed, and the run time in milliseconds.
+function parrot_mock --argument-names cmd exit_code stages duration_ms
+    set -l aliases
+    test $exit_code -eq 127; and set aliases (parrot_aliases)
+    test -n "$duration_ms"; or set duration_ms 0
+    set -l mock env COLUMNS=$COLUMNS PARROT_ALIASES="$aliases" $PARROT_BIN mock --pipestatus "$stages" --duration "$duration_ms"ms $cmd $exit_code
+    if test "$PARROT_STDERR" = true
+        if test "$PARROT_ASYNC" = true
+            $mock >&2 &
+        else
+            $mock >&2
+        end
+    else if test "$PARROT_ASYNC" = true
+        $mock &
+    else
+        $mock
+    end
+end
+
+# Whether the command-not-found handler already mocked this prompt's failure
+function parrot_not_found_handled
+    test -e $PARROT_NOT_FOUND_MARK; or return 1
+    rm -f $PARROT_NOT_FOUND_MARK
+end
+
+# Function to check if parrot binary exists
+function parrot_check
+    if not command -q $PARROT_BIN
+        echo "⚠️  Parrot binary not found at $PARROT_BIN. Run 'parrot install' again after moving it."
+        return 1
+    end
+end
+
+# Called after each interactive command with the command line; $status is
+# the command's exit code, $pipestatus that of each pipeline stage and
+# $CMD_DURATION its run time in milliseconds
+function parrot_postexec --on-event fish_postexec
+    set -l codes $status $pipestatus
+    set -l exit_code $codes[1]
+    set -l stages (string join ' ' -- $codes[2..-1])
+    set -l duration_ms $CMD_DURATION
+    set -l cmd (string trim -- $argv[1] | string collect)
+    test -z "$cmd"; and return
+
+    parrot_record $cmd $exit_code $duration_ms
+    set -l handled (parrot_not_found_handled; and echo 1)
+
+    # Only mock if the command, or a stage of its pipeline, failed. SIGPIPE
+    # (141) only means a later stage stopped reading. The failure is
+    # remembered, so the command finally working can be reported too and earn
+    # a backhanded congratulation.
+    if test $exit_code -ne 0; or string match -qvr '^(0|141)$' -- $codes[2..-1]
+        set -g PARROT_FAILED_CMD $cmd
+        test -z "$handled"; and parrot_check; and parrot_mock $cmd $exit_code $stages $duration_ms
+    else if test "$cmd" = "$PARROT_FAILED_CMD"
+        set -g PARROT_FAILED_CMD
+        parrot_check; and parrot_mock $cmd 0 $stages $duration_ms
+    else if set -q PARROT_SLOW_AFTER; and test "$duration_ms" -ge (math $PARROT_SLOW_AFTER \* 1000)
+        # Successes that took forever may get a comment on the wait.
+        # parrot init sets PARROT_SLOW_AFTER to the shortest threshold.
+        parrot_check; and parrot_mock $cmd 0 $stages $duration_ms
+    end
+end
+
+# Key binding widget: put the suggested fix for the last failure on the
+# command line, where it can be edited and run by the shell itself
+function parrot_fix_widget
+    set -l fix ($PARROT_BIN fix --print 2>/dev/null | string collect); or return
+    commandline -r -- $fix
+    commandline -C (string length -- $fix)
+end
+
+# Optional command-not-found handler (PARROT_NOT_FOUND_HANDLER=true): the
+# parrot suggests the command you meant as soon as the shell gives up on one.
+# A handler that was already installed still runs first.
+if test "$PARROT_NOT_FOUND_HANDLER" = true
+    if functions -q fish_command_not_found; and not functions -q parrot_previous_not_found
+        functions -c fish_command_not_found parrot_previous_not_found
+    end
+    function fish_command_not_found
+        if functions -q parrot_previous_not_found
+            parrot_previous_not_found $argv
+        else
+            printf 'fish: Unknown command: %s\n' $argv[1] >&2
+        end
+        if parrot_check
+            touch $PARROT_NOT_FOUND_MARK
+            parrot_mock "$argv" 127
+        end
+    end
+end
+
+# Optional fix key, in bind notation, e.g. PARROT_FIX_KEY=\cx\cf
+if set -q PARROT_FIX_KEY; and status is-interactive
+    bind $PARROT_FIX_KEY parrot_fix_widget
+end
+echo "🦜 Parrot is now watching your fish commands..."
+
+# Show performance tip
+if test "$PARROT_ASYNC" != true
+    echo "💡 Tip: Set PARROT_ASYNC=true to prevent terminal hangs on slow networks"
+end
@@ -0,0 +1,212 @@
+// Package hooks generates the shell integration that reports failed commands
+// to parrot. The scripts are embedded in the binary, so `parrot init <shell>`
+// works however parrot was installed.
+package hooks
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"parrot/internal/redact"
+)
+
+//go:embed common.sh
+var common string
+
+//go:embed bash.sh
+var bashSetup string
+
+//go:embed zsh.sh
+var zshSetup string
+
+//go:embed fish.fish
+var fishScript string
+
+//go:embed nu.nu
+var nuScript string
+
+//go:embed xonsh.xsh
+var xonshScript string
+
+// syntax is how a shell's generated header sets variables and how its startup
+// file loads the generated code
+type syntax struct {
+	set        func(name, value string) string // Assign a quoted value
+	setDefault func(name, value string) string // Assign unless the environment already has a value
+	load       string                          // Format of the startup line, given the init command
+	usage      string                          // How to load the code, given the shell
+	saved      bool                            // The startup file sources a saved copy of the code
+	quote      func(value string) string
+}
+
+var posix = syntax{
+	set: func(name, value string) string {
+		return fmt.Sprintf("%s=%s", name, value)
+	},
+	setDefault: func(name, value string) string {
+		return fmt.Sprintf(`%s=${%s:-%s}`, name, name, value)
+	},
+	load:  `eval "$(%s)"`,
+	usage: `eval "$(parrot init %s)"`,
+	quote: quote,
+}
+
+var fish = syntax{
+	set: func(name, value string) string {
+		return fmt.Sprintf("set -g %s %s", name, value)
+	},
+	setDefault: func(name, value string) string {
+		return fmt.Sprintf("set -q %s; or set -g %s %s", name, name, value)
+	},
+	load:  "%s | source",
+	usage: "parrot init %s | source",
+	quote: func(value string) string {
+		return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value) + "'"
+	},
+}
+
+// nushell can only source files known when the config is parsed, so its
+// code is saved to a file that config.nu sources
+var nushell = syntax{
+	set: func(name, value string) string {
+		return fmt.Sprintf("$env.%s = %s", name, value)
+	},
+	setDefault: func(name, value string) string {
+		return fmt.Sprintf("$env.%s = ($env.%s? | default %s)", name, name, value)
+	},
+	load:  "source %s",
+	usage: "parrot init %s | save --force parrot.nu, then source parrot.nu from config.nu",
+	saved: true,
+	quote: func(value string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	},
+}
+
+var xonsh = syntax{
+	set: func(name, value string) string {
+		return fmt.Sprintf("$%s = %s", name, value)
+	},
+	setDefault: func(name, value string) string {
+		return fmt.Sprintf("$%s = ${...}.get('%s', %s)", name, name, value)
+	},
+	load:  "execx($(%s))",
+	usage: "execx($(parrot init %s))",
+	quote: strconv.Quote,
+}
+
+// shell is the hook code of one shell
+type shell struct {
+	script string
+	syntax syntax
+}
+
+// shells holds the hook code of each supported shell. bash and zsh share the
+// POSIX functions and differ in how they hook into the prompt.
+var shells = map[string]shell{
+	"bash":  {script: common + "\n" + bashSetup, syntax: posix},
+	"zsh":   {script: common + "\n" + zshSetup, syntax: posix},
+	"fish":  {script: fishScript, syntax: fish},
+	"nu":    {script: nuScript, syntax: nushell},
+	"xonsh": {script: xonshScript, syntax: xonsh},
+}
+
+// Options are baked into the generated script. The environment variables they
+// set can still be overridden before the script is loaded.
+type Options struct {
+	Binary  string // Absolute path of the parrot binary
+	Version string // Version of the binary generating the script
+	Async   bool   // Mock in the background by default (PARROT_ASYNC)
+	Stderr  bool   // Print roasts on stderr by default (PARROT_STDERR)
+
+	// Keep no session transcript by default (PARROT_TRANSCRIPT)
+	NoTranscript bool
+
+	// Seconds a successful command must run before it is reported, in case
+	// it was slow (PARROT_SLOW_AFTER); 0 reports none
+	SlowAfter int
+}
+
+// Shells lists the supported shells.
+func Shells() []string {
+	names := make([]string, 0, len(shells))
+	for name := range shells {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Supported reports whether a script can be generated for shell.
+func Supported(shell string) bool {
+	_, exists := shells[shell]
+	return exists
+}
+
+// Script returns the hook code for shell, meant to be evaluated by it.
+func Script(shell string, opts Options) (string, error) {
+	target, exists := shells[shell]
+	if !exists {
+		return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(Shells(), ", "))
+	}
+	syntax := target.syntax
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "# parrot %s integration, generated by parrot %s\n", shell, opts.Version)
+	fmt.Fprintf(&script, "# Load it with: %s\n\n", fmt.Sprintf(syntax.usage, shell))
+	script.WriteString(syntax.set("PARROT_HOOK_VERSION", syntax.quote(opts.Version)) + "\n")
+	script.WriteString(syntax.set("PARROT_BIN", syntax.quote(opts.Binary)) + "\n")
+	if opts.Async {
+		script.WriteString(syntax.setDefault("PARROT_ASYNC", syntax.quote("true")) + "\n")
+	}
+	if opts.Stderr {
+		script.WriteString(syntax.setDefault("PARROT_STDERR", syntax.quote("true")) + "\n")
+	}
+	if opts.NoTranscript {
+		script.WriteString(syntax.setDefault("PARROT_TRANSCRIPT", syntax.quote("false")) + "\n")
+	}
+	// Commands matching it are never written to the transcript
+	script.WriteString(syntax.set("PARROT_SECRET_PATTERN", syntax.quote(redact.Marker)) + "\n")
+	if opts.SlowAfter > 0 {
+		script.WriteString(syntax.setDefault("PARROT_SLOW_AFTER", syntax.quote(strconv.Itoa(opts.SlowAfter))) + "\n")
+	}
+	script.WriteString("\n" + target.script)
+	return script.String(), nil
+}
+
+// quote single-quotes a value for POSIX shells
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// InitLine returns the startup file line that loads the hook code for shell,
+// passing the options along as flags.
+func InitLine(shell string, opts Options) string {
+	syntax := posix
+	if target, exists := shells[shell]; exists {
+		syntax = target.syntax
+	}
+	command := syntax.quote(opts.Binary) + " init " + shell
+	if opts.Async {
+		command += " --async"
+	}
+	if opts.Stderr {
+		command += " --stderr"
+	}
+	return fmt.Sprintf(syntax.load, command)
+}
+
+// Saved reports whether shell can't load generated code at startup, so the
+// code has to be saved to a file its startup file sources.
+func Saved(shell string) bool {
+	return shells[shell].syntax.saved
+}
+
+// SourceLine returns the startup file line that sources hook code saved at
+// path, for shells where Saved is true.
+func SourceLine(shell, path string) string {
+	syntax := shells[shell].syntax
+	return fmt.Sprintf(syntax.load, syntax.quote(path))
+}
@@ -0,0 +1,23 @@
+# Zsh setup
+autoload -Uz add-zsh-hook
+zmodload zsh/datetime 2>/dev/null
+add-zsh-hook preexec parrot_preexec
+add-zsh-hook precmd parrot_precmd
+if [ "${PARROT_NOT_FOUND_HANDLER:-}" = "true" ]; then
+    if [ -n "${functions[command_not_found_handler]:-}" ] &&
+        [[ "${functions[command_not_found_handler]}" != *parrot_not_found* ]]; then
+        functions[parrot_previous_not_found]="${functions[command_not_found_handler]}"
+    fi
+    command_not_found_handler() { parrot_not_found "$@"; }
+fi
+# Optional fix key, in bindkey notation, e.g. PARROT_FIX_KEY='^X^F'
+if [ -n "${PARROT_FIX_KEY:-}" ] && [[ -o interactive ]]; then
+    zle -N parrot_fix_widget
+    bindkey "$PARROT_FIX_KEY" parrot_fix_widget
+fi
+echo "🦜 Parrot is now watching your zsh commands..."
+
+# Show performance tip
+if [ "${PARROT_ASYNC:-}" != "true" ]; then
+    echo "💡 Tip: Set PARROT_ASYNC=true to prevent terminal hangs on slow networks"
+fi
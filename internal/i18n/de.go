@@ -0,0 +1,161 @@
+package i18n
+
+var german = map[string]string{
+	// status
+	"🦜 Parrot Status Report":                                 "🦜 Parrot-Statusbericht",
+	"❌ Configuration Error: %v":                              "❌ Konfigurationsfehler: %v",
+	"📁 Configuration:":                                       "📁 Konfiguration:",
+	"✅ Loaded from: %s":                                      "✅ Geladen aus: %s",
+	"ℹ️  Using default configuration (no config file found)": "ℹ️  Standardkonfiguration aktiv (keine Konfigurationsdatei gefunden)",
+	"• Personality: %s":                                      "• Persönlichkeit: %s",
+	"• Debug mode: %t":                                       "• Debug-Modus: %t",
+	"• Fallback only: %t":                                    "• Nur Fallback: %t",
+	"• Offline style: %s":                                    "• Offline-Stil: %s",
+	"• Response style: %s":                                   "• Antwortstil: %s",
+	"• Language: %s":                                         "• Sprache: %s",
+	"• Snoozed: quiet until 'parrot quiet off'":              "• Pausiert: still bis 'parrot quiet off'",
+	"• Snoozed: %s left":                                     "• Pausiert: noch %s",
+	"🌐 API Backend:":                                         "🌐 API-Backend:",
+	"• Enabled: ✅":                                           "• Aktiviert: ✅",
+	"• Enabled: ❌":                                           "• Aktiviert: ❌",
+	"• Enabled: ❌ (no API key configured)":                   "• Aktiviert: ❌ (kein API-Schlüssel konfiguriert)",
+	"• Provider: %s":                                         "• Anbieter: %s",
+	"• Model: %s":                                            "• Modell: %s",
+	"• Status: ✅ Available":                                  "• Status: ✅ Verfügbar",
+	"• Status: ❌ Unavailable (check API key/endpoint)":       "• Status: ❌ Nicht verfügbar (API-Schlüssel/Endpunkt prüfen)",
+	"🖥️  Local Backend:":                                     "🖥️  Lokales Backend:",
+	"• Status: ❌ Unavailable (check if Ollama is running)":   "• Status: ❌ Nicht verfügbar (läuft Ollama?)",
+	"🔄 Fallback Backend:":                                    "🔄 Fallback-Backend:",
+	"• Status: ✅ Always available":                           "• Status: ✅ Immer verfügbar",
+	"⚡ Backend Priority:":                                    "⚡ Backend-Reihenfolge:",
+	"1. 🔄 Fallback (forced)":                                 "1. 🔄 Fallback (erzwungen)",
+	"%d. 🌐 API (ready)":                                      "%d. 🌐 API (bereit)",
+	"%d. 🌐 API (unavailable)":                                "%d. 🌐 API (nicht verfügbar)",
+	"%d. 🖥️  Local (ready)":                                  "%d. 🖥️  Lokal (bereit)",
+	"%d. 🖥️  Local (unavailable)":                            "%d. 🖥️  Lokal (nicht verfügbar)",
+	"%d. 🔄 Fallback (always)":                                "%d. 🔄 Fallback (immer)",
+	"💡 Quick Setup:":                                         "💡 Schnelleinrichtung:",
+	`• Set API key: export PARROT_API_KEY="your-key-here"`:   `• API-Schlüssel setzen: export PARROT_API_KEY="dein-schlüssel"`,
+	"• Install model: ollama pull %s":                        "• Modell installieren: ollama pull %s",
+	"📈 Last 7 Days:":                                         "📈 Letzte 7 Tage:",
+	"• Failures: %d":                                         "• Fehlschläge: %d",
+	"• Roasted: %d":                                          "• Verspottet: %d",
+	"• Suppressed: %d":                                       "• Unterdrückt: %d",
+	"📖 Use 'parrot config' to create a configuration file":   "📖 Mit 'parrot config' eine Konfigurationsdatei anlegen",
+
+	// setup
+	"🦜 Welcome to Parrot Complete Setup!":                           "🦜 Willkommen bei der Parrot-Einrichtung!",
+	"Let's get your sassy parrot fully operational!":                "Machen wir deinen frechen Papagei startklar!",
+	"📊 System Check":                                                "📊 Systemprüfung",
+	"📋 No config found - will create one":                           "📋 Keine Konfiguration gefunden - wird angelegt",
+	"✅ Config loaded":                                               "✅ Konfiguration geladen",
+	"• API Backend:":                                                "• API-Backend:",
+	"✅ Ready":                                                       "✅ Bereit",
+	"⚠️  Key set but unavailable":                                   "⚠️  Schlüssel gesetzt, aber nicht erreichbar",
+	"❌ No API key":                                                  "❌ Kein API-Schlüssel",
+	"• Ollama Installed:":                                           "• Ollama installiert:",
+	"✅ Yes":                                                         "✅ Ja",
+	"❌ Not found":                                                   "❌ Nicht gefunden",
+	"• Local Model Ready:":                                          "• Lokales Modell bereit:",
+	"✅ Available":                                                   "✅ Verfügbar",
+	"❌ Model %s not found":                                          "❌ Modell %s nicht gefunden",
+	"❌ Ollama not installed":                                        "❌ Ollama nicht installiert",
+	"🎉 Intelligence Available!":                                     "🎉 Intelligenz verfügbar!",
+	"✅ API Backend ready (%s)":                                      "✅ API-Backend bereit (%s)",
+	"✅ Local Backend ready (%s)":                                    "✅ Lokales Backend bereit (%s)",
+	"🐚 Final Step: Shell Integration":                               "🐚 Letzter Schritt: Shell-Integration",
+	"🚀 Choose Your Intelligence Level":                              "🚀 Wähle deine Intelligenzstufe",
+	"1. 🌐 API Backend (Fast, requires internet & key)":              "1. 🌐 API-Backend (schnell, braucht Internet & Schlüssel)",
+	"2. 🖥️  Local Backend (Private, requires download)":             "2. 🖥️  Lokales Backend (privat, braucht Download)",
+	"3. 🔄 Fallback Only (Basic responses, works now)":               "3. 🔄 Nur Fallback (einfache Antworten, sofort startklar)",
+	"Choose setup path [1-3]:":                                      "Einrichtung wählen [1-3]:",
+	"✅ Using fallback responses - no setup needed!":                 "✅ Fallback-Antworten aktiv - keine Einrichtung nötig!",
+	"❌ Please choose 1, 2, or 3":                                    "❌ Bitte 1, 2 oder 3 wählen",
+	"🐚 Shell Integration":                                           "🐚 Shell-Integration",
+	"🤖 Local Model Setup":                                           "🤖 Einrichtung des lokalen Modells",
+	"Ollama is installed. Would you like to install %s now? [y/N]:": "Ollama ist installiert. %s jetzt installieren? [y/N]:",
+	"📥 Installing %s (this may take a few minutes)...":              "📥 Installiere %s (das kann ein paar Minuten dauern)...",
+	"❌ Failed to install model: %v":                                 "❌ Modell konnte nicht installiert werden: %v",
+	"Please run manually: ollama pull %s":                           "Bitte manuell ausführen: ollama pull %s",
+	"✅ Model installed successfully!":                               "✅ Modell erfolgreich installiert!",
+	"❌ Ollama not found. Please install from: https://ollama.com/download":         "❌ Ollama nicht gefunden. Installation unter: https://ollama.com/download",
+	"To automatically roast failed commands:":                                      "Damit fehlgeschlagene Befehle automatisch verspottet werden:",
+	"1. Run: parrot install":                                                       "1. Ausführen: parrot install",
+	"2. Restart your shell or run: %s":                                             "2. Shell neu starten oder ausführen: %s",
+	"3. Try failing a command and watch parrot respond!":                           "3. Lass einen Befehl scheitern und sieh dem Papagei zu!",
+	"🔧 Useful Commands":                                                            "🔧 Nützliche Befehle",
+	"• parrot status         - Check backend status":                               "• parrot status         - Backend-Status prüfen",
+	"• parrot configure      - Interactive configuration":                          "• parrot configure      - Interaktive Konfiguration",
+	"• parrot mock <cmd> <code> - Test responses":                                  "• parrot mock <cmd> <code> - Antworten testen",
+	"• PARROT_DEBUG=true     - Enable debug output":                                "• PARROT_DEBUG=true     - Debug-Ausgabe aktivieren",
+	"🎉 Happy failing! Your parrot is ready to roast you.":                          "🎉 Viel Spaß beim Scheitern! Dein Papagei ist bereit, dich zu verspotten.",
+	"🌐 API Backend Setup":                                                          "🌐 Einrichtung des API-Backends",
+	"For AI-powered responses, you need an API key:":                               "Für KI-Antworten brauchst du einen API-Schlüssel:",
+	"• OpenAI: https://platform.openai.com/api-keys (recommended)":                 "• OpenAI: https://platform.openai.com/api-keys (empfohlen)",
+	"Enter your API key (or press Enter to skip):":                                 "API-Schlüssel eingeben (oder Enter zum Überspringen):",
+	"Provider [openai]:":                                                           "Anbieter [openai]:",
+	"⚠️  Couldn't save config: %v":                                                 "⚠️  Konfiguration konnte nicht gespeichert werden: %v",
+	`💡 You can set it later with: export PARROT_API_KEY="your-key"`:                `💡 Später setzen mit: export PARROT_API_KEY="dein-schlüssel"`,
+	"✅ API key saved to config!":                                                   "✅ API-Schlüssel in der Konfiguration gespeichert!",
+	"🧪 Testing API connection...":                                                  "🧪 Teste API-Verbindung...",
+	"✅ API backend is working!":                                                    "✅ API-Backend funktioniert!",
+	"⚠️  API test failed - check your key and try again":                           "⚠️  API-Test fehlgeschlagen - Schlüssel prüfen und erneut versuchen",
+	"⏭️  Skipped API setup - you can configure later with: parrot configure":       "⏭️  API-Einrichtung übersprungen - später mit: parrot configure",
+	"🖥️ Local Backend Setup":                                                       "🖥️ Einrichtung des lokalen Backends",
+	"❌ Ollama not found. Installing...":                                            "❌ Ollama nicht gefunden. Installation...",
+	"📥 Please install Ollama first:":                                               "📥 Bitte zuerst Ollama installieren:",
+	"• Or visit: https://ollama.com/download":                                      "• Oder besuche: https://ollama.com/download",
+	"Press Enter after installing Ollama...":                                       "Nach der Installation von Ollama Enter drücken...",
+	"❌ Ollama still not found. Please install it and run setup again.":             "❌ Ollama immer noch nicht gefunden. Bitte installieren und Einrichtung erneut starten.",
+	"✅ Ollama detected!":                                                           "✅ Ollama erkannt!",
+	"📥 Installing model %s (this may take a few minutes)...":                       "📥 Installiere Modell %s (das kann ein paar Minuten dauern)...",
+	"💡 Try manually: ollama pull %s":                                               "💡 Manuell versuchen: ollama pull %s",
+	"✅ Local backend configured!":                                                  "✅ Lokales Backend konfiguriert!",
+	"✅ Local AI is ready!":                                                         "✅ Lokale KI ist bereit!",
+	"1. 📥 Install shell hooks":                                                     "1. 📥 Shell-Hooks installieren",
+	"Install now? [Y/n]:":                                                          "Jetzt installieren? [Y/n]:",
+	"Running: parrot install":                                                      "Führe aus: parrot install",
+	"⏭️  Skipped - run 'parrot install' later to enable auto-roasting":             "⏭️  Übersprungen - später 'parrot install' ausführen, um das automatische Verspotten zu aktivieren",
+	"🧪 Test Your Parrot":                                                           "🧪 Teste deinen Papagei",
+	`Try: parrot mock "git push" "1"`:                                              `Probier: parrot mock "git push" "1"`,
+	`Or try savage mode: PARROT_PERSONALITY=savage parrot mock "docker run" "125"`: `Oder den gnadenlosen Modus: PARROT_PERSONALITY=savage parrot mock "docker run" "125"`,
+	"🎉 Setup Complete!":                                                            "🎉 Einrichtung abgeschlossen!",
+	"Your parrot is ready to roast your failures! 🦜💥":                              "Dein Papagei ist bereit, deine Fehlschläge zu verspotten! 🦜💥",
+
+	// configure
+	"🦜 Parrot Configuration Wizard":               "🦜 Parrot-Konfigurationsassistent",
+	"🌐 API Backend Configuration":                 "🌐 Konfiguration des API-Backends",
+	"🖥️  Local Backend Configuration":             "🖥️  Konfiguration des lokalen Backends",
+	"⚙️  General Preferences":                     "⚙️  Allgemeine Einstellungen",
+	"💾 Saving Configuration...":                   "💾 Speichere Konfiguration...",
+	"❌ Error creating config template: %v":        "❌ Fehler beim Anlegen der Konfigurationsvorlage: %v",
+	"❌ Error saving configuration: %v":            "❌ Fehler beim Speichern der Konfiguration: %v",
+	"✅ Configuration saved to: %s":                "✅ Konfiguration gespeichert unter: %s",
+	"🎯 Next Steps:":                               "🎯 Nächste Schritte:",
+	"• Test API backend: parrot status":           "• API-Backend testen: parrot status",
+	"• Ensure model is available: ollama pull %s": "• Sicherstellen, dass das Modell da ist: ollama pull %s",
+	`• Test parrot: parrot mock "git push" "1"`:   `• Parrot testen: parrot mock "git push" "1"`,
+	"• Install shell hooks: parrot install":       "• Shell-Hooks installieren: parrot install",
+	"📁 Configuration Location":                    "📁 Speicherort der Konfiguration",
+	"Choose where to save your configuration:":    "Wähle, wo die Konfiguration gespeichert wird:",
+	"Choice [1]:": "Auswahl [1]:",
+	"Choice:":     "Auswahl:",
+	"❌ Invalid choice. Please enter a number between 1 and %d": "❌ Ungültige Auswahl. Bitte eine Zahl zwischen 1 und %d eingeben",
+	"❌ Invalid choice. Please enter 1-%d or the option name.":  "❌ Ungültige Auswahl. Bitte 1-%d oder den Namen der Option eingeben.",
+	"Enable API backend? (recommended)":                        "API-Backend aktivieren? (empfohlen)",
+	"API Provider":                                             "API-Anbieter",
+	"API Endpoint URL":                                         "URL des API-Endpunkts",
+	"API Key":                                                  "API-Schlüssel",
+	"Model name":                                               "Modellname",
+	"Enable local Ollama backend?":                             "Lokales Ollama-Backend aktivieren?",
+	"Ollama endpoint":                                          "Ollama-Endpunkt",
+	"Local model":                                              "Lokales Modell",
+	"Custom model name":                                        "Eigener Modellname",
+	"Personality level":                                        "Persönlichkeit",
+	"Language":                                                 "Sprache",
+	"Enable debug mode?":                                       "Debug-Modus aktivieren?",
+	"Use only fallback responses? (disable AI)":                "Nur Fallback-Antworten verwenden? (KI deaktivieren)",
+	"Show a fix suggestion under the roast?":                   "Einen Lösungsvorschlag unter dem Spott anzeigen?",
+	"Offline response style":                                   "Stil der Offline-Antworten",
+	"Response style":                                           "Antwortstil",
+}
@@ -0,0 +1,161 @@
+package i18n
+
+var spanish = map[string]string{
+	// status
+	"🦜 Parrot Status Report":                                 "🦜 Informe de estado de Parrot",
+	"❌ Configuration Error: %v":                              "❌ Error de configuración: %v",
+	"📁 Configuration:":                                       "📁 Configuración:",
+	"✅ Loaded from: %s":                                      "✅ Cargada desde: %s",
+	"ℹ️  Using default configuration (no config file found)": "ℹ️  Usando la configuración predeterminada (no se encontró archivo)",
+	"• Personality: %s":                                      "• Personalidad: %s",
+	"• Debug mode: %t":                                       "• Modo depuración: %t",
+	"• Fallback only: %t":                                    "• Solo respaldo: %t",
+	"• Offline style: %s":                                    "• Estilo sin conexión: %s",
+	"• Response style: %s":                                   "• Estilo de respuesta: %s",
+	"• Language: %s":                                         "• Idioma: %s",
+	"• Snoozed: quiet until 'parrot quiet off'":              "• En pausa: en silencio hasta 'parrot quiet off'",
+	"• Snoozed: %s left":                                     "• En pausa: quedan %s",
+	"🌐 API Backend:":                                         "🌐 Backend de API:",
+	"• Enabled: ✅":                                           "• Activado: ✅",
+	"• Enabled: ❌":                                           "• Activado: ❌",
+	"• Enabled: ❌ (no API key configured)":                   "• Activado: ❌ (no hay clave de API configurada)",
+	"• Provider: %s":                                         "• Proveedor: %s",
+	"• Model: %s":                                            "• Modelo: %s",
+	"• Status: ✅ Available":                                  "• Estado: ✅ Disponible",
+	"• Status: ❌ Unavailable (check API key/endpoint)":       "• Estado: ❌ No disponible (revisa la clave/endpoint de la API)",
+	"🖥️  Local Backend:":                                     "🖥️  Backend local:",
+	"• Status: ❌ Unavailable (check if Ollama is running)":   "• Estado: ❌ No disponible (¿está Ollama en marcha?)",
+	"🔄 Fallback Backend:":                                    "🔄 Backend de respaldo:",
+	"• Status: ✅ Always available":                           "• Estado: ✅ Siempre disponible",
+	"⚡ Backend Priority:":                                    "⚡ Prioridad de backends:",
+	"1. 🔄 Fallback (forced)":                                 "1. 🔄 Respaldo (forzado)",
+	"%d. 🌐 API (ready)":                                      "%d. 🌐 API (lista)",
+	"%d. 🌐 API (unavailable)":                                "%d. 🌐 API (no disponible)",
+	"%d. 🖥️  Local (ready)":                                  "%d. 🖥️  Local (listo)",
+	"%d. 🖥️  Local (unavailable)":                            "%d. 🖥️  Local (no disponible)",
+	"%d. 🔄 Fallback (always)":                                "%d. 🔄 Respaldo (siempre)",
+	"💡 Quick Setup:":                                         "💡 Configuración rápida:",
+	`• Set API key: export PARROT_API_KEY="your-key-here"`:   `• Define la clave de API: export PARROT_API_KEY="tu-clave"`,
+	"• Install model: ollama pull %s":                        "• Instala el modelo: ollama pull %s",
+	"📈 Last 7 Days:":                                         "📈 Últimos 7 días:",
+	"• Failures: %d":                                         "• Fallos: %d",
+	"• Roasted: %d":                                          "• Burlados: %d",
+	"• Suppressed: %d":                                       "• Silenciados: %d",
+	"📖 Use 'parrot config' to create a configuration file":   "📖 Usa 'parrot config' para crear un archivo de configuración",
+
+	// setup
+	"🦜 Welcome to Parrot Complete Setup!":                           "🦜 ¡Bienvenido a la instalación completa de Parrot!",
+	"Let's get your sassy parrot fully operational!":                "¡Pongamos a punto a tu loro descarado!",
+	"📊 System Check":                                                "📊 Comprobación del sistema",
+	"📋 No config found - will create one":                           "📋 No hay configuración - se creará una",
+	"✅ Config loaded":                                               "✅ Configuración cargada",
+	"• API Backend:":                                                "• Backend de API:",
+	"✅ Ready":                                                       "✅ Listo",
+	"⚠️  Key set but unavailable":                                   "⚠️  Clave definida pero no disponible",
+	"❌ No API key":                                                  "❌ Sin clave de API",
+	"• Ollama Installed:":                                           "• Ollama instalado:",
+	"✅ Yes":                                                         "✅ Sí",
+	"❌ Not found":                                                   "❌ No encontrado",
+	"• Local Model Ready:":                                          "• Modelo local listo:",
+	"✅ Available":                                                   "✅ Disponible",
+	"❌ Model %s not found":                                          "❌ Modelo %s no encontrado",
+	"❌ Ollama not installed":                                        "❌ Ollama no está instalado",
+	"🎉 Intelligence Available!":                                     "🎉 ¡Inteligencia disponible!",
+	"✅ API Backend ready (%s)":                                      "✅ Backend de API listo (%s)",
+	"✅ Local Backend ready (%s)":                                    "✅ Backend local listo (%s)",
+	"🐚 Final Step: Shell Integration":                               "🐚 Último paso: integración con la shell",
+	"🚀 Choose Your Intelligence Level":                              "🚀 Elige tu nivel de inteligencia",
+	"1. 🌐 API Backend (Fast, requires internet & key)":              "1. 🌐 Backend de API (rápido, requiere internet y clave)",
+	"2. 🖥️  Local Backend (Private, requires download)":             "2. 🖥️  Backend local (privado, requiere descarga)",
+	"3. 🔄 Fallback Only (Basic responses, works now)":               "3. 🔄 Solo respaldo (respuestas básicas, funciona ya)",
+	"Choose setup path [1-3]:":                                      "Elige una opción [1-3]:",
+	"✅ Using fallback responses - no setup needed!":                 "✅ Usando respuestas de respaldo - ¡no hace falta configurar nada!",
+	"❌ Please choose 1, 2, or 3":                                    "❌ Elige 1, 2 o 3",
+	"🐚 Shell Integration":                                           "🐚 Integración con la shell",
+	"🤖 Local Model Setup":                                           "🤖 Configuración del modelo local",
+	"Ollama is installed. Would you like to install %s now? [y/N]:": "Ollama está instalado. ¿Instalar %s ahora? [y/N]:",
+	"📥 Installing %s (this may take a few minutes)...":              "📥 Instalando %s (puede tardar unos minutos)...",
+	"❌ Failed to install model: %v":                                 "❌ No se pudo instalar el modelo: %v",
+	"Please run manually: ollama pull %s":                           "Ejecútalo a mano: ollama pull %s",
+	"✅ Model installed successfully!":                               "✅ ¡Modelo instalado correctamente!",
+	"❌ Ollama not found. Please install from: https://ollama.com/download":         "❌ Ollama no encontrado. Instálalo desde: https://ollama.com/download",
+	"To automatically roast failed commands:":                                      "Para burlarse automáticamente de los comandos fallidos:",
+	"1. Run: parrot install":                                                       "1. Ejecuta: parrot install",
+	"2. Restart your shell or run: %s":                                             "2. Reinicia la shell o ejecuta: %s",
+	"3. Try failing a command and watch parrot respond!":                           "3. ¡Haz fallar un comando y mira cómo responde el loro!",
+	"🔧 Useful Commands":                                                            "🔧 Comandos útiles",
+	"• parrot status         - Check backend status":                               "• parrot status         - Ver el estado de los backends",
+	"• parrot configure      - Interactive configuration":                          "• parrot configure      - Configuración interactiva",
+	"• parrot mock <cmd> <code> - Test responses":                                  "• parrot mock <cmd> <code> - Probar respuestas",
+	"• PARROT_DEBUG=true     - Enable debug output":                                "• PARROT_DEBUG=true     - Activar la depuración",
+	"🎉 Happy failing! Your parrot is ready to roast you.":                          "🎉 ¡Feliz fracaso! Tu loro está listo para burlarse de ti.",
+	"🌐 API Backend Setup":                                                          "🌐 Configuración del backend de API",
+	"For AI-powered responses, you need an API key:":                               "Para respuestas con IA necesitas una clave de API:",
+	"• OpenAI: https://platform.openai.com/api-keys (recommended)":                 "• OpenAI: https://platform.openai.com/api-keys (recomendado)",
+	"Enter your API key (or press Enter to skip):":                                 "Introduce tu clave de API (o pulsa Enter para omitir):",
+	"Provider [openai]:":                                                           "Proveedor [openai]:",
+	"⚠️  Couldn't save config: %v":                                                 "⚠️  No se pudo guardar la configuración: %v",
+	`💡 You can set it later with: export PARROT_API_KEY="your-key"`:                `💡 Puedes definirla más tarde con: export PARROT_API_KEY="tu-clave"`,
+	"✅ API key saved to config!":                                                   "✅ ¡Clave de API guardada en la configuración!",
+	"🧪 Testing API connection...":                                                  "🧪 Probando la conexión con la API...",
+	"✅ API backend is working!":                                                    "✅ ¡El backend de API funciona!",
+	"⚠️  API test failed - check your key and try again":                           "⚠️  Falló la prueba de la API - revisa tu clave y vuelve a intentarlo",
+	"⏭️  Skipped API setup - you can configure later with: parrot configure":       "⏭️  Configuración de API omitida - puedes hacerla luego con: parrot configure",
+	"🖥️ Local Backend Setup":                                                       "🖥️ Configuración del backend local",
+	"❌ Ollama not found. Installing...":                                            "❌ Ollama no encontrado. Instalando...",
+	"📥 Please install Ollama first:":                                               "📥 Instala Ollama primero:",
+	"• Or visit: https://ollama.com/download":                                      "• O visita: https://ollama.com/download",
+	"Press Enter after installing Ollama...":                                       "Pulsa Enter después de instalar Ollama...",
+	"❌ Ollama still not found. Please install it and run setup again.":             "❌ Sigue sin encontrarse Ollama. Instálalo y vuelve a ejecutar la configuración.",
+	"✅ Ollama detected!":                                                           "✅ ¡Ollama detectado!",
+	"📥 Installing model %s (this may take a few minutes)...":                       "📥 Instalando el modelo %s (puede tardar unos minutos)...",
+	"💡 Try manually: ollama pull %s":                                               "💡 Prueba a mano: ollama pull %s",
+	"✅ Local backend configured!":                                                  "✅ ¡Backend local configurado!",
+	"✅ Local AI is ready!":                                                         "✅ ¡La IA local está lista!",
+	"1. 📥 Install shell hooks":                                                     "1. 📥 Instalar los hooks de la shell",
+	"Install now? [Y/n]:":                                                          "¿Instalar ahora? [Y/n]:",
+	"Running: parrot install":                                                      "Ejecutando: parrot install",
+	"⏭️  Skipped - run 'parrot install' later to enable auto-roasting":             "⏭️  Omitido - ejecuta 'parrot install' más tarde para activar las burlas automáticas",
+	"🧪 Test Your Parrot":                                                           "🧪 Prueba tu loro",
+	`Try: parrot mock "git push" "1"`:                                              `Prueba: parrot mock "git push" "1"`,
+	`Or try savage mode: PARROT_PERSONALITY=savage parrot mock "docker run" "125"`: `O prueba el modo despiadado: PARROT_PERSONALITY=savage parrot mock "docker run" "125"`,
+	"🎉 Setup Complete!":                                                            "🎉 ¡Configuración completa!",
+	"Your parrot is ready to roast your failures! 🦜💥":                              "¡Tu loro está listo para burlarse de tus fracasos! 🦜💥",
+
+	// configure
+	"🦜 Parrot Configuration Wizard":               "🦜 Asistente de configuración de Parrot",
+	"🌐 API Backend Configuration":                 "🌐 Configuración del backend de API",
+	"🖥️  Local Backend Configuration":             "🖥️  Configuración del backend local",
+	"⚙️  General Preferences":                     "⚙️  Preferencias generales",
+	"💾 Saving Configuration...":                   "💾 Guardando la configuración...",
+	"❌ Error creating config template: %v":        "❌ Error al crear la plantilla de configuración: %v",
+	"❌ Error saving configuration: %v":            "❌ Error al guardar la configuración: %v",
+	"✅ Configuration saved to: %s":                "✅ Configuración guardada en: %s",
+	"🎯 Next Steps:":                               "🎯 Próximos pasos:",
+	"• Test API backend: parrot status":           "• Probar el backend de API: parrot status",
+	"• Ensure model is available: ollama pull %s": "• Asegúrate de tener el modelo: ollama pull %s",
+	`• Test parrot: parrot mock "git push" "1"`:   `• Probar parrot: parrot mock "git push" "1"`,
+	"• Install shell hooks: parrot install":       "• Instalar los hooks de la shell: parrot install",
+	"📁 Configuration Location":                    "📁 Ubicación de la configuración",
+	"Choose where to save your configuration:":    "Elige dónde guardar la configuración:",
+	"Choice [1]:": "Opción [1]:",
+	"Choice:":     "Opción:",
+	"❌ Invalid choice. Please enter a number between 1 and %d": "❌ Opción no válida. Introduce un número entre 1 y %d",
+	"❌ Invalid choice. Please enter 1-%d or the option name.":  "❌ Opción no válida. Introduce 1-%d o el nombre de la opción.",
+	"Enable API backend? (recommended)":                        "¿Activar el backend de API? (recomendado)",
+	"API Provider":                                             "Proveedor de API",
+	"API Endpoint URL":                                         "URL del endpoint de la API",
+	"API Key":                                                  "Clave de API",
+	"Model name":                                               "Nombre del modelo",
+	"Enable local Ollama backend?":                             "¿Activar el backend local de Ollama?",
+	"Ollama endpoint":                                          "Endpoint de Ollama",
+	"Local model":                                              "Modelo local",
+	"Custom model name":                                        "Nombre de modelo personalizado",
+	"Personality level":                                        "Nivel de personalidad",
+	"Language":                                                 "Idioma",
+	"Enable debug mode?":                                       "¿Activar el modo depuración?",
+	"Use only fallback responses? (disable AI)":                "¿Usar solo respuestas de respaldo? (desactiva la IA)",
+	"Show a fix suggestion under the roast?":                   "¿Mostrar una sugerencia de arreglo bajo la burla?",
+	"Offline response style":                                   "Estilo de las respuestas sin conexión",
+	"Response style":                                           "Estilo de respuesta",
+}
@@ -0,0 +1,141 @@
+// Package i18n selects the language parrot speaks and translates its
+// user-facing strings. Messages are looked up by their English text, so a
+// missing translation simply stays English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// English is the source language and the fallback for everything else.
+const English = "en"
+
+// languages maps supported language codes to their English names, which is
+// how prompts ask a model to respond in them
+var languages = map[string]string{
+	"en": "English",
+	"de": "German",
+	"es": "Spanish",
+	"ja": "Japanese",
+}
+
+// Supported lists the language codes in the order they are offered.
+var Supported = []string{"en", "de", "es", "ja"}
+
+// catalogs hold the translations of each language, keyed by English message
+var catalogs = map[string]map[string]string{
+	"de": german,
+	"es": spanish,
+	"ja": japanese,
+}
+
+// Resolve turns a language setting into a supported language code. An empty
+// or "auto" setting follows LC_ALL, LC_MESSAGES and LANG; anything
+// unsupported falls back to English.
+func Resolve(setting string) string {
+	if setting == "" || setting == "auto" {
+		setting = fromEnvironment()
+	}
+	if code := normalize(setting); languages[code] != "" {
+		return code
+	}
+	return English
+}
+
+// Name returns the English name of a language code, e.g. "German" for "de".
+func Name(code string) string {
+	if name, exists := languages[code]; exists {
+		return name
+	}
+	return languages[English]
+}
+
+// Valid reports whether a language setting names a supported language or
+// asks for automatic detection.
+func Valid(setting string) bool {
+	return setting == "" || setting == "auto" || languages[normalize(setting)] != ""
+}
+
+func fromEnvironment() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return English
+}
+
+// normalize reduces a locale such as "de_DE.UTF-8" or "ja-JP" to its language
+func normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if end := strings.IndexAny(locale, "_-.@"); end != -1 {
+		locale = locale[:end]
+	}
+	if locale == "c" || locale == "posix" {
+		return English
+	}
+	return locale
+}
+
+// Printer prints messages in one language.
+type Printer struct {
+	lang    string
+	catalog map[string]string
+}
+
+// NewPrinter returns a printer for a language code.
+func NewPrinter(lang string) *Printer {
+	return &Printer{lang: lang, catalog: catalogs[lang]}
+}
+
+// Language returns the printer's language code.
+func (p *Printer) Language() string {
+	return p.lang
+}
+
+// T translates a message. Surrounding whitespace, such as indentation and
+// trailing newlines, isn't part of the lookup and is kept as is.
+func (p *Printer) T(message string) string {
+	core := strings.TrimFunc(message, unicode.IsSpace)
+	if core == "" {
+		return message
+	}
+	translated, exists := p.catalog[core]
+	if !exists {
+		return message
+	}
+	start := strings.Index(message, core)
+	return message[:start] + translated + message[start+len(core):]
+}
+
+// Sprintf formats a translated format string.
+func (p *Printer) Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(p.T(format), args...)
+}
+
+// Printf prints a translated format string.
+func (p *Printer) Printf(format string, args ...interface{}) {
+	fmt.Printf(p.T(format), args...)
+}
+
+// Print prints its operands like fmt.Print, translating a leading message.
+func (p *Printer) Print(args ...interface{}) {
+	fmt.Print(p.translateFirst(args)...)
+}
+
+// Println prints its operands like fmt.Println, translating a leading message.
+func (p *Printer) Println(args ...interface{}) {
+	fmt.Println(p.translateFirst(args)...)
+}
+
+func (p *Printer) translateFirst(args []interface{}) []interface{} {
+	if len(args) > 0 {
+		if message, isString := args[0].(string); isString {
+			args[0] = p.T(message)
+		}
+	}
+	return args
+}
@@ -0,0 +1,161 @@
+package i18n
+
+var japanese = map[string]string{
+	// status
+	"🦜 Parrot Status Report":                                 "🦜 Parrot ステータス",
+	"❌ Configuration Error: %v":                              "❌ 設定エラー: %v",
+	"📁 Configuration:":                                       "📁 設定:",
+	"✅ Loaded from: %s":                                      "✅ 読み込み元: %s",
+	"ℹ️  Using default configuration (no config file found)": "ℹ️  デフォルト設定を使用中（設定ファイルが見つかりません）",
+	"• Personality: %s":                                      "• 性格: %s",
+	"• Debug mode: %t":                                       "• デバッグモード: %t",
+	"• Fallback only: %t":                                    "• フォールバックのみ: %t",
+	"• Offline style: %s":                                    "• オフライン時のスタイル: %s",
+	"• Response style: %s":                                   "• 応答スタイル: %s",
+	"• Language: %s":                                         "• 言語: %s",
+	"• Snoozed: quiet until 'parrot quiet off'":              "• スヌーズ中: 'parrot quiet off' まで静かにします",
+	"• Snoozed: %s left":                                     "• スヌーズ中: 残り %s",
+	"🌐 API Backend:":                                         "🌐 API バックエンド:",
+	"• Enabled: ✅":                                           "• 有効: ✅",
+	"• Enabled: ❌":                                           "• 有効: ❌",
+	"• Enabled: ❌ (no API key configured)":                   "• 有効: ❌（API キーが未設定）",
+	"• Provider: %s":                                         "• プロバイダー: %s",
+	"• Model: %s":                                            "• モデル: %s",
+	"• Status: ✅ Available":                                  "• 状態: ✅ 利用可能",
+	"• Status: ❌ Unavailable (check API key/endpoint)":       "• 状態: ❌ 利用不可（API キーとエンドポイントを確認）",
+	"🖥️  Local Backend:":                                     "🖥️  ローカルバックエンド:",
+	"• Status: ❌ Unavailable (check if Ollama is running)":   "• 状態: ❌ 利用不可（Ollama が起動しているか確認）",
+	"🔄 Fallback Backend:":                                    "🔄 フォールバック:",
+	"• Status: ✅ Always available":                           "• 状態: ✅ 常に利用可能",
+	"⚡ Backend Priority:":                                    "⚡ バックエンドの優先順位:",
+	"1. 🔄 Fallback (forced)":                                 "1. 🔄 フォールバック（強制）",
+	"%d. 🌐 API (ready)":                                      "%d. 🌐 API（準備完了）",
+	"%d. 🌐 API (unavailable)":                                "%d. 🌐 API（利用不可）",
+	"%d. 🖥️  Local (ready)":                                  "%d. 🖥️  ローカル（準備完了）",
+	"%d. 🖥️  Local (unavailable)":                            "%d. 🖥️  ローカル（利用不可）",
+	"%d. 🔄 Fallback (always)":                                "%d. 🔄 フォールバック（常時）",
+	"💡 Quick Setup:":                                         "💡 クイックセットアップ:",
+	`• Set API key: export PARROT_API_KEY="your-key-here"`:   `• API キーを設定: export PARROT_API_KEY="あなたのキー"`,
+	"• Install model: ollama pull %s":                        "• モデルをインストール: ollama pull %s",
+	"📈 Last 7 Days:":                                         "📈 過去7日間:",
+	"• Failures: %d":                                         "• 失敗: %d",
+	"• Roasted: %d":                                          "• いじった回数: %d",
+	"• Suppressed: %d":                                       "• 抑制: %d",
+	"📖 Use 'parrot config' to create a configuration file":   "📖 'parrot config' で設定ファイルを作成できます",
+
+	// setup
+	"🦜 Welcome to Parrot Complete Setup!":                           "🦜 Parrot セットアップへようこそ！",
+	"Let's get your sassy parrot fully operational!":                "生意気なオウムを準備しましょう！",
+	"📊 System Check":                                                "📊 システムチェック",
+	"📋 No config found - will create one":                           "📋 設定が見つかりません - 新しく作成します",
+	"✅ Config loaded":                                               "✅ 設定を読み込みました",
+	"• API Backend:":                                                "• API バックエンド:",
+	"✅ Ready":                                                       "✅ 準備完了",
+	"⚠️  Key set but unavailable":                                   "⚠️  キーは設定済みですが利用できません",
+	"❌ No API key":                                                  "❌ API キーなし",
+	"• Ollama Installed:":                                           "• Ollama のインストール:",
+	"✅ Yes":                                                         "✅ はい",
+	"❌ Not found":                                                   "❌ 見つかりません",
+	"• Local Model Ready:":                                          "• ローカルモデル:",
+	"✅ Available":                                                   "✅ 利用可能",
+	"❌ Model %s not found":                                          "❌ モデル %s が見つかりません",
+	"❌ Ollama not installed":                                        "❌ Ollama が未インストール",
+	"🎉 Intelligence Available!":                                     "🎉 AI が使えます！",
+	"✅ API Backend ready (%s)":                                      "✅ API バックエンド準備完了（%s）",
+	"✅ Local Backend ready (%s)":                                    "✅ ローカルバックエンド準備完了（%s）",
+	"🐚 Final Step: Shell Integration":                               "🐚 最後のステップ: シェル連携",
+	"🚀 Choose Your Intelligence Level":                              "🚀 AI のレベルを選んでください",
+	"1. 🌐 API Backend (Fast, requires internet & key)":              "1. 🌐 API バックエンド（高速、インターネットとキーが必要）",
+	"2. 🖥️  Local Backend (Private, requires download)":             "2. 🖥️  ローカルバックエンド（プライベート、ダウンロードが必要）",
+	"3. 🔄 Fallback Only (Basic responses, works now)":               "3. 🔄 フォールバックのみ（基本的な応答、すぐ使えます）",
+	"Choose setup path [1-3]:":                                      "セットアップ方法を選択 [1-3]:",
+	"✅ Using fallback responses - no setup needed!":                 "✅ フォールバック応答を使用します - 設定は不要です！",
+	"❌ Please choose 1, 2, or 3":                                    "❌ 1、2、3 のいずれかを選んでください",
+	"🐚 Shell Integration":                                           "🐚 シェル連携",
+	"🤖 Local Model Setup":                                           "🤖 ローカルモデルのセットアップ",
+	"Ollama is installed. Would you like to install %s now? [y/N]:": "Ollama はインストール済みです。%s を今すぐインストールしますか？ [y/N]:",
+	"📥 Installing %s (this may take a few minutes)...":              "📥 %s をインストール中（数分かかることがあります）...",
+	"❌ Failed to install model: %v":                                 "❌ モデルのインストールに失敗しました: %v",
+	"Please run manually: ollama pull %s":                           "手動で実行してください: ollama pull %s",
+	"✅ Model installed successfully!":                               "✅ モデルをインストールしました！",
+	"❌ Ollama not found. Please install from: https://ollama.com/download":         "❌ Ollama が見つかりません。こちらからインストールしてください: https://ollama.com/download",
+	"To automatically roast failed commands:":                                      "失敗したコマンドを自動でいじるには:",
+	"1. Run: parrot install":                                                       "1. 実行: parrot install",
+	"2. Restart your shell or run: %s":                                             "2. シェルを再起動するか実行: %s",
+	"3. Try failing a command and watch parrot respond!":                           "3. コマンドを失敗させて、オウムの反応を見てみましょう！",
+	"🔧 Useful Commands":                                                            "🔧 便利なコマンド",
+	"• parrot status         - Check backend status":                               "• parrot status         - バックエンドの状態を確認",
+	"• parrot configure      - Interactive configuration":                          "• parrot configure      - 対話式の設定",
+	"• parrot mock <cmd> <code> - Test responses":                                  "• parrot mock <cmd> <code> - 応答をテスト",
+	"• PARROT_DEBUG=true     - Enable debug output":                                "• PARROT_DEBUG=true     - デバッグ出力を有効化",
+	"🎉 Happy failing! Your parrot is ready to roast you.":                          "🎉 存分に失敗してください！オウムの準備はできています。",
+	"🌐 API Backend Setup":                                                          "🌐 API バックエンドのセットアップ",
+	"For AI-powered responses, you need an API key:":                               "AI の応答には API キーが必要です:",
+	"• OpenAI: https://platform.openai.com/api-keys (recommended)":                 "• OpenAI: https://platform.openai.com/api-keys（推奨）",
+	"Enter your API key (or press Enter to skip):":                                 "API キーを入力（Enter でスキップ）:",
+	"Provider [openai]:":                                                           "プロバイダー [openai]:",
+	"⚠️  Couldn't save config: %v":                                                 "⚠️  設定を保存できませんでした: %v",
+	`💡 You can set it later with: export PARROT_API_KEY="your-key"`:                `💡 後から設定できます: export PARROT_API_KEY="あなたのキー"`,
+	"✅ API key saved to config!":                                                   "✅ API キーを設定に保存しました！",
+	"🧪 Testing API connection...":                                                  "🧪 API 接続をテスト中...",
+	"✅ API backend is working!":                                                    "✅ API バックエンドは動作しています！",
+	"⚠️  API test failed - check your key and try again":                           "⚠️  API テストに失敗しました - キーを確認して再試行してください",
+	"⏭️  Skipped API setup - you can configure later with: parrot configure":       "⏭️  API の設定をスキップしました - 後で parrot configure で設定できます",
+	"🖥️ Local Backend Setup":                                                       "🖥️ ローカルバックエンドのセットアップ",
+	"❌ Ollama not found. Installing...":                                            "❌ Ollama が見つかりません。インストールします...",
+	"📥 Please install Ollama first:":                                               "📥 まず Ollama をインストールしてください:",
+	"• Or visit: https://ollama.com/download":                                      "• または: https://ollama.com/download",
+	"Press Enter after installing Ollama...":                                       "Ollama をインストールしたら Enter を押してください...",
+	"❌ Ollama still not found. Please install it and run setup again.":             "❌ Ollama がまだ見つかりません。インストールしてからセットアップをやり直してください。",
+	"✅ Ollama detected!":                                                           "✅ Ollama を検出しました！",
+	"📥 Installing model %s (this may take a few minutes)...":                       "📥 モデル %s をインストール中（数分かかることがあります）...",
+	"💡 Try manually: ollama pull %s":                                               "💡 手動で試してください: ollama pull %s",
+	"✅ Local backend configured!":                                                  "✅ ローカルバックエンドを設定しました！",
+	"✅ Local AI is ready!":                                                         "✅ ローカル AI の準備ができました！",
+	"1. 📥 Install shell hooks":                                                     "1. 📥 シェルフックをインストール",
+	"Install now? [Y/n]:":                                                          "今すぐインストールしますか？ [Y/n]:",
+	"Running: parrot install":                                                      "実行中: parrot install",
+	"⏭️  Skipped - run 'parrot install' later to enable auto-roasting":             "⏭️  スキップしました - 自動でいじるには後で 'parrot install' を実行してください",
+	"🧪 Test Your Parrot":                                                           "🧪 オウムをテスト",
+	`Try: parrot mock "git push" "1"`:                                              `試してみよう: parrot mock "git push" "1"`,
+	`Or try savage mode: PARROT_PERSONALITY=savage parrot mock "docker run" "125"`: `辛口モードも: PARROT_PERSONALITY=savage parrot mock "docker run" "125"`,
+	"🎉 Setup Complete!":                                                            "🎉 セットアップ完了！",
+	"Your parrot is ready to roast your failures! 🦜💥":                              "オウムはあなたの失敗をいじる準備ができました！ 🦜💥",
+
+	// configure
+	"🦜 Parrot Configuration Wizard":               "🦜 Parrot 設定ウィザード",
+	"🌐 API Backend Configuration":                 "🌐 API バックエンドの設定",
+	"🖥️  Local Backend Configuration":             "🖥️  ローカルバックエンドの設定",
+	"⚙️  General Preferences":                     "⚙️  一般設定",
+	"💾 Saving Configuration...":                   "💾 設定を保存中...",
+	"❌ Error creating config template: %v":        "❌ 設定テンプレートの作成エラー: %v",
+	"❌ Error saving configuration: %v":            "❌ 設定の保存エラー: %v",
+	"✅ Configuration saved to: %s":                "✅ 設定を保存しました: %s",
+	"🎯 Next Steps:":                               "🎯 次のステップ:",
+	"• Test API backend: parrot status":           "• API バックエンドをテスト: parrot status",
+	"• Ensure model is available: ollama pull %s": "• モデルを用意: ollama pull %s",
+	`• Test parrot: parrot mock "git push" "1"`:   `• Parrot をテスト: parrot mock "git push" "1"`,
+	"• Install shell hooks: parrot install":       "• シェルフックをインストール: parrot install",
+	"📁 Configuration Location":                    "📁 設定の保存場所",
+	"Choose where to save your configuration:":    "設定の保存場所を選んでください:",
+	"Choice [1]:": "選択 [1]:",
+	"Choice:":     "選択:",
+	"❌ Invalid choice. Please enter a number between 1 and %d": "❌ 無効な選択です。1 から %d の数字を入力してください",
+	"❌ Invalid choice. Please enter 1-%d or the option name.":  "❌ 無効な選択です。1-%d またはオプション名を入力してください。",
+	"Enable API backend? (recommended)":                        "API バックエンドを有効にしますか？（推奨）",
+	"API Provider":                                             "API プロバイダー",
+	"API Endpoint URL":                                         "API エンドポイント URL",
+	"API Key":                                                  "API キー",
+	"Model name":                                               "モデル名",
+	"Enable local Ollama backend?":                             "ローカルの Ollama バックエンドを有効にしますか？",
+	"Ollama endpoint":                                          "Ollama エンドポイント",
+	"Local model":                                              "ローカルモデル",
+	"Custom model name":                                        "カスタムモデル名",
+	"Personality level":                                        "性格",
+	"Language":                                                 "言語",
+	"Enable debug mode?":                                       "デバッグモードを有効にしますか？",
+	"Use only fallback responses? (disable AI)":                "フォールバック応答のみを使いますか？（AI を無効化）",
+	"Show a fix suggestion under the roast?":                   "いじりの下に修正案を表示しますか？",
+	"Offline response style":                                   "オフライン時の応答スタイル",
+	"Response style":                                           "応答スタイル",
+}
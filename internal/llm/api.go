@@ -55,15 +55,19 @@ func NewAPIClient(endpoint, apiKey, model string, timeout int) *APIClient {
 	}
 }
 
-func (c *APIClient) Generate(ctx context.Context, prompt string) (string, error) {
+func (c *APIClient) Generate(ctx context.Context, system, prompt string) (string, error) {
 	if c.APIKey == "" {
 		return "", fmt.Errorf("API key not configured")
 	}
 
-	// Build chat request
+	// Build chat request, keeping instructions and untrusted data in separate messages
 	req := ChatRequest{
 		Model: c.Model,
 		Messages: []ChatMessage{
+			{
+				Role:    "system",
+				Content: system,
+			},
 			{
 				Role:    "user",
 				Content: prompt,
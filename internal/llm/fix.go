@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"parrot/internal/policy"
+	"parrot/internal/prompts"
+	"parrot/internal/redact"
+)
+
+// maxFixLength bounds a suggested command; anything longer is commentary
+const maxFixLength = 300
+
+// SuggestFix asks the model backends for a corrected version of the failed
+// command in req, whose Prompt should come from prompts.BuildFixPrompt. It
+// returns an empty command when the policy or fallback mode keeps the failure
+// away from models, or when no backend offered a usable fix.
+func (m *LLMManager) SuggestFix(ctx context.Context, req Request) (string, Backend) {
+	decision := m.Decide(req)
+	if m.config.General.Debug && decision.Rule != "" {
+		fmt.Printf("🛡️  Policy %q applies (%s): %s\n", decision.Rule, decision.Reason, decision.Action)
+	}
+	if decision.Action == policy.ActionSilent || decision.Action == policy.ActionFallbackOnly || m.config.General.FallbackMode {
+		return "", BackendNone
+	}
+
+	command, backend := m.queryModels(ctx, req.Prompt, decision, cleanFix)
+	if command == "" || command == strings.TrimSpace(req.Command) {
+		return "", BackendNone
+	}
+	return command, backend
+}
+
+// cleanFix reduces a model reply to the single command it suggests, or to
+// nothing if the model had no fix.
+func cleanFix(response string) string {
+	response = strings.TrimSpace(response)
+
+	// Take the first line that isn't a code fence
+	command := ""
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "```") {
+			command = line
+			break
+		}
+	}
+
+	command = strings.TrimPrefix(command, "$ ")
+	command = strings.Trim(command, "`")
+	if len(command) >= 2 && command[0] == '"' && command[len(command)-1] == '"' {
+		command = command[1 : len(command)-1]
+	}
+	command = strings.TrimSpace(prompts.Unescape(command))
+
+	switch {
+	case strings.EqualFold(strings.Trim(command, "."), prompts.NoFix):
+		return ""
+	case strings.Contains(command, redact.Mask):
+		// Running a command with a masked secret in it can only fail
+		return ""
+	case len(command) > maxFixLength:
+		return ""
+	}
+	return command
+}
@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"parrot/internal/prompts"
+)
+
+// leakMarkers are phrases that only show up when a model talks about its
+// instructions instead of following them
+var leakMarkers = []string{
+	"failure_data",
+	"system prompt",
+	"these instructions",
+	"my instructions",
+	"untrusted text",
+	"ignore previous instructions",
+	"ignore all previous instructions",
+}
+
+// echoWindow is how many consecutive instruction words count as an echo
+const echoWindow = 6
+
+// screenResponse cleans a backend response and rejects it if it appears to
+// leak instructions or credentials, so a steered model can't print them.
+func (m *LLMManager) screenResponse(response string, sent prompts.Prompt, clean func(string) string) (string, error) {
+	response = clean(response)
+	if response == "" {
+		return "", fmt.Errorf("empty response after cleanup")
+	}
+
+	if reason, leaked := m.detectLeak(response, sent); leaked {
+		return "", fmt.Errorf("response rejected: %s", reason)
+	}
+	return response, nil
+}
+
+func (m *LLMManager) detectLeak(response string, sent prompts.Prompt) (string, bool) {
+	lower := strings.ToLower(response)
+
+	// Anything that looks like a key, token or password
+	if _, matches := m.redactor.Redact(response); len(matches) > 0 {
+		return fmt.Sprintf("contains a secret-like string (%s)", matches[0].Detector), true
+	}
+	if key := m.config.API.APIKey; len(key) >= 8 && strings.Contains(response, key) {
+		return "contains the configured API key", true
+	}
+
+	for _, marker := range leakMarkers {
+		if strings.Contains(lower, marker) {
+			return fmt.Sprintf("mentions %q", marker), true
+		}
+	}
+
+	// Verbatim runs of the system instructions
+	if echoesInstructions(lower, strings.ToLower(sent.System)) {
+		return "echoes the system instructions", true
+	}
+	return "", false
+}
+
+// echoesInstructions reports whether the response repeats echoWindow or more
+// consecutive words of the instructions. Example lines are skipped since
+// repeating one of those is merely unoriginal.
+func echoesInstructions(response, instructions string) bool {
+	responseWords := strings.Join(strings.Fields(normalize(response)), " ")
+	if responseWords == "" {
+		return false
+	}
+
+	for _, line := range strings.Split(instructions, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "- ") {
+			continue
+		}
+		words := strings.Fields(normalize(line))
+		for i := 0; i+echoWindow <= len(words); i++ {
+			if strings.Contains(responseWords, strings.Join(words[i:i+echoWindow], " ")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalize drops punctuation so quoting or trailing periods don't hide an echo
+func normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == ' ', r == '\n', r == '\'':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return ' '
+		}
+	}, text)
+}
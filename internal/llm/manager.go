@@ -5,14 +5,27 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"parrot/internal/cmdline"
 	"parrot/internal/config"
+	"parrot/internal/exitcode"
+	"parrot/internal/fallback"
+	"parrot/internal/i18n"
+	"parrot/internal/packs"
+	"parrot/internal/policy"
+	"parrot/internal/prompts"
+	"parrot/internal/redact"
+	"parrot/internal/style"
+	"parrot/internal/tips"
 )
 
 type LLMManager struct {
 	config     *config.Config
 	apiClient  *APIClient
 	ollamaClient *OllamaClient
+	redactor   *redact.Redactor
+	policy     *policy.Policy
 }
 
 type Backend string
@@ -21,13 +34,44 @@ const (
 	BackendAPI      Backend = "api"
 	BackendLocal    Backend = "local"  
 	BackendFallback Backend = "fallback"
+	BackendNone     Backend = "none" // Policy forbade any response
 )
 
+// Request describes the failure a response is being generated for.
+type Request struct {
+	Prompt      prompts.Prompt
+	Command     string
+	Parsed      *cmdline.Command
+	Cwd         string
+	CommandType string
+	Exit        exitcode.Info
+	Similar     []string      // Known commands near a name that wasn't found
+	Attempts    int           // Failures a command that succeeded put an end to
+	Stuck       time.Duration // Time those failures took
+	Duration    time.Duration // How long the command ran; zero if unknown
+	Slow        bool          // A success being remarked on for how long it took
+	Packs       []packs.Line  // Installed pack lines for the offline fallback
+}
+
 func NewLLMManager(cfg *config.Config) *LLMManager {
 	manager := &LLMManager{
 		config: cfg,
 	}
 	
+	// Build the secret redactor used before prompts leave the machine
+	redactor, err := redact.New(cfg.Redaction.Patterns)
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	manager.redactor = redactor
+	
+	// Build the privacy policy that decides which backends may see a command
+	commandPolicy, err := policy.New(cfg.Policy)
+	if err != nil && cfg.General.Debug {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	manager.policy = commandPolicy
+	
 	// Initialize API client if enabled
 	if cfg.API.Enabled && cfg.API.APIKey != "" {
 		manager.apiClient = NewAPIClient(
@@ -60,23 +104,62 @@ func NewLLMManager(cfg *config.Config) *LLMManager {
 	return manager
 }
 
-func (m *LLMManager) Generate(ctx context.Context, prompt string, commandType string) (string, Backend) {
+// Decide evaluates the privacy policy for a request
+func (m *LLMManager) Decide(req Request) policy.Decision {
+	return m.policy.Evaluate(policy.Input{
+		Command: req.Command,
+		Parsed:  req.Parsed,
+		Cwd:     req.Cwd,
+		Type:    req.CommandType,
+	})
+}
+
+func (m *LLMManager) Generate(ctx context.Context, req Request) (string, Backend) {
+	// Enforce the privacy policy before choosing any backend
+	decision := m.Decide(req)
+	if m.config.General.Debug && decision.Rule != "" {
+		fmt.Printf("🛡️  Policy %q applies (%s): %s\n", decision.Rule, decision.Reason, decision.Action)
+	}
+	switch decision.Action {
+	case policy.ActionSilent:
+		return "", BackendNone
+	case policy.ActionFallbackOnly:
+		return m.generateFallback(req), BackendFallback
+	}
+	
 	// If fallback mode is enabled, skip LLM backends
 	if m.config.General.FallbackMode {
-		return m.generateFallback(commandType), BackendFallback
+		return m.generateFallback(req), BackendFallback
 	}
 	
 	// Try backends in priority order: API -> Local -> Fallback
+	if response, backend := m.queryModels(ctx, req.Prompt, decision, m.cleanResponse); response != "" {
+		return response, backend
+	}
 	
-	// 1. Try API first (if available)
-	if m.apiClient != nil && m.config.API.Enabled {
+	// 3. Fallback to hardcoded responses
+	if m.config.General.Debug {
+		fmt.Printf("🔄 Using fallback backend\n")
+	}
+	return m.generateFallback(req), BackendFallback
+}
+
+// queryModels tries the API and local backends the decision allows, in
+// priority order, and returns the first response that survives cleanup and
+// screening. It returns an empty response when no model answered.
+func (m *LLMManager) queryModels(ctx context.Context, prompt prompts.Prompt, decision policy.Decision, clean func(string) string) (string, Backend) {
+	// 1. Try API first (if available and allowed)
+	if m.apiClient != nil && m.config.API.Enabled && decision.AllowsAPI() {
 		if m.config.General.Debug {
 			fmt.Printf("🔍 Trying API backend...\n")
 		}
 		
-		response, err := m.apiClient.Generate(ctx, prompt)
+		sent := m.redactPrompt(prompt, m.config.Redaction.Enabled)
+		response, err := m.apiClient.Generate(ctx, sent.System, sent.User)
+		if err == nil && response != "" {
+			response, err = m.screenResponse(response, sent, clean)
+		}
 		if err == nil && response != "" {
-			response = m.cleanResponse(response)
 			if m.config.General.Debug {
 				fmt.Printf("✅ API backend succeeded\n")
 			}
@@ -88,8 +171,8 @@ func (m *LLMManager) Generate(ctx context.Context, prompt string, commandType st
 		}
 	}
 	
-	// 2. Try local Ollama (if available)
-	if m.ollamaClient != nil && m.config.Local.Enabled {
+	// 2. Try local Ollama (if available and allowed)
+	if m.ollamaClient != nil && m.config.Local.Enabled && decision.AllowsLocal() {
 		if m.config.General.Debug {
 			fmt.Printf("🔍 Trying local backend...\n")
 		}
@@ -102,9 +185,12 @@ func (m *LLMManager) Generate(ctx context.Context, prompt string, commandType st
 		localCtx, cancel := context.WithTimeout(ctx, timeoutDuration)
 		defer cancel()
 		
-		response, err := m.ollamaClient.Generate(localCtx, prompt)
+		sent := m.redactPrompt(prompt, m.config.Redaction.Enabled && m.config.Redaction.RedactLocal)
+		response, err := m.ollamaClient.Generate(localCtx, sent.System, sent.User)
+		if err == nil && response != "" {
+			response, err = m.screenResponse(response, sent, clean)
+		}
 		if err == nil && response != "" {
-			response = m.cleanResponse(response)
 			if m.config.General.Debug {
 				fmt.Printf("✅ Local backend succeeded\n")
 			}
@@ -116,32 +202,75 @@ func (m *LLMManager) Generate(ctx context.Context, prompt string, commandType st
 		}
 	}
 	
-	// 3. Fallback to hardcoded responses
-	if m.config.General.Debug {
-		fmt.Printf("🔄 Using fallback backend\n")
+	return "", BackendNone
+}
+
+// PreviewPrompt returns a prompt as the given backend would receive it, along
+// with what was masked
+func (m *LLMManager) PreviewPrompt(prompt prompts.Prompt, backend Backend) (prompts.Prompt, []redact.Match) {
+	enabled := m.config.Redaction.Enabled
+	if backend == BackendLocal {
+		enabled = enabled && m.config.Redaction.RedactLocal
+	}
+	if !enabled {
+		return prompt, nil
+	}
+	return m.redact(prompt)
+}
+
+func (m *LLMManager) redact(prompt prompts.Prompt) (prompts.Prompt, []redact.Match) {
+	system, systemMatches := m.redactor.Redact(prompt.System)
+	user, userMatches := m.redactor.Redact(prompt.User)
+	return prompts.Prompt{System: system, User: user}, append(systemMatches, userMatches...)
+}
+
+// redactPrompt masks secrets in a prompt when enabled, reporting what was
+// masked in debug mode
+func (m *LLMManager) redactPrompt(prompt prompts.Prompt, enabled bool) prompts.Prompt {
+	if !enabled {
+		return prompt
+	}
+	
+	redacted, matches := m.redact(prompt)
+	if m.config.General.Debug && len(matches) > 0 {
+		fmt.Printf("🔒 Masked %d secret(s) before sending:\n", len(matches))
+		for _, match := range matches {
+			fmt.Printf("   • %s\n", match)
+		}
 	}
-	return m.generateFallback(commandType), BackendFallback
+	return redacted
+}
+
+// responsePrefixes are labels models put before their answer
+var responsePrefixes = []string{
+	"Response:",
+	"Parrot says:",
+	"🦜",
 }
 
 func (m *LLMManager) cleanResponse(response string) string {
+	// Multi-line styles keep their shape
+	if form := style.Get(m.config.General.Style); form.Multiline() {
+		return m.cleanVerse(response, form)
+	}
+	
 	// Clean up the response
 	response = strings.TrimSpace(response)
 	
 	// Split at newlines and only keep the first meaningful part
 	lines := strings.Split(response, "\n")
+	tip := ""
 	if len(lines) > 1 {
-		// Keep only the first line, discard any commentary after newlines
+		// Keep only the first line and a requested tip, discard any other commentary
 		response = strings.TrimSpace(lines[0])
+		tip = findTip(lines[1:])
 	}
-	
-	// Remove common prefixes from LLMs
-	prefixes := []string{
-		"Response:",
-		"Parrot says:",
-		"🦜",
+	if roast, inline, found := strings.Cut(response, " "+tips.Prefix); found {
+		response, tip = strings.TrimSpace(roast), strings.TrimSpace(inline)
 	}
 	
-	for _, prefix := range prefixes {
+	// Remove common prefixes from LLMs
+	for _, prefix := range responsePrefixes {
 		if strings.HasPrefix(response, prefix) {
 			response = strings.TrimSpace(response[len(prefix):])
 		}
@@ -178,62 +307,102 @@ func (m *LLMManager) cleanResponse(response string) string {
 		if idx := strings.LastIndex(response[:150], "."); idx > 50 {
 			response = response[:idx+1]
 		} else {
-			response = response[:147] + "..."
+			response = truncate(response, 150)
 		}
 	}
 	
-	return strings.TrimSpace(response)
+	response = strings.TrimSpace(response)
+	if tip != "" && response != "" {
+		response += "\n" + tips.Prefix + " " + truncateTip(tip)
+	}
+	return response
 }
 
-func (m *LLMManager) generateFallback(commandType string) string {
-	fallbacks := map[string][]string{
-		"git": {
-			"Git good? More like git rekt!",
-			"Did you forget to pull again? Classic amateur move.",
-			"Another git genius strikes again!",
-			"Your commits are as broken as your workflow.",
-		},
-		"nodejs": {
-			"NPM install failed? Shocking! Nobody saw that coming.",
-			"Your package.json is crying. Fix it.",
-			"Node modules: where dependencies go to die.",
-			"Even npm doesn't want to deal with your code.",
-		},
-		"docker": {
-			"Docker container more like docker DISASTER!",
-			"Even containers can't contain your incompetence.",
-			"Your Dockerfile needs therapy.",
-			"Container exit code: user error detected.",
-		},
-		"http": {
-			"404: Competence not found.",
-			"Even the internet doesn't want to talk to you.",
-			"Connection refused? So is your logic.",
-			"HTTP status: 500 Internal User Error.",
-		},
-		"generic": {
-			"Wow, you managed to break something simple. Impressive!",
-			"Maybe try reading the manual... oh wait, who am I kidding?",
-			"Error code says it all: user error!",
-			"Have you tried turning your brain on and off again?",
-		},
+// cleanVerse keeps the lines of a multi-line style such as a haiku. Replies
+// without the style's structure are rejected so the next backend, or the
+// fallback, answers instead.
+func (m *LLMManager) cleanVerse(response string, form style.Style) string {
+	var verse []string
+	tip := ""
+	for _, line := range strings.Split(strings.TrimSpace(response), "\n") {
+		line = strings.Trim(strings.TrimSpace(line), "*_\"")
+		for _, prefix := range responsePrefixes {
+			line = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+		
+		switch {
+		case line == "", strings.HasPrefix(line, "```"):
+			continue
+		case strings.HasPrefix(line, tips.Prefix):
+			if tip == "" {
+				tip = strings.TrimSpace(strings.TrimPrefix(line, tips.Prefix))
+			}
+		case len(verse) == 0 && strings.HasSuffix(line, ":"):
+			// A title such as "Haiku:" isn't part of the verse
+		default:
+			verse = append(verse, line)
+		}
 	}
 	
-	responses, exists := fallbacks[commandType]
-	if !exists {
-		responses = fallbacks["generic"]
+	// Syllables can only be counted in English; other languages get the shape checked
+	validate := form.Validate
+	if i18n.Resolve(m.config.General.Language) != i18n.English {
+		validate = form.ValidateShape
+	}
+	if err := validate(verse); err != nil {
+		if m.config.General.Debug {
+			fmt.Printf("📏 Response doesn't fit the %s style: %v\n", form.Name, err)
+		}
+		return ""
 	}
 	
-	// Simple pseudo-random selection based on command type
-	hash := 0
-	for _, char := range commandType {
-		hash = hash*31 + int(char)
+	response = strings.Join(verse, "\n")
+	if tip != "" {
+		response += "\n" + tips.Prefix + " " + truncateTip(tip)
 	}
-	if hash < 0 {
-		hash = -hash
+	return response
+}
+
+// findTip returns the text of the first "Tip:" line
+func findTip(lines []string) string {
+	for _, line := range lines {
+		line = strings.Trim(strings.TrimSpace(line), "*_")
+		if strings.HasPrefix(line, tips.Prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, tips.Prefix))
+		}
 	}
-	
-	return responses[hash%len(responses)]
+	return ""
+}
+
+func truncateTip(tip string) string {
+	return truncate(tip, 150)
+}
+
+// truncate shortens text to at most limit bytes without splitting a character
+func truncate(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	cut := limit - 3
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
+
+func (m *LLMManager) generateFallback(req Request) string {
+	return fallback.Default(m.config.General.FallbackStyle, req.Packs).Respond(fallback.Situation{
+		Personality: m.config.General.Personality,
+		Type:        req.CommandType,
+		Exit:        req.Exit,
+		Parsed:      req.Parsed,
+		Similar:     req.Similar,
+		Language:    i18n.Resolve(m.config.General.Language),
+		Attempts:    req.Attempts,
+		Stuck:       req.Stuck,
+		Duration:    req.Duration,
+		Slow:        req.Slow,
+	})
 }
 
 func (m *LLMManager) GetStatus() map[string]interface{} {
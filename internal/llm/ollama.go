@@ -18,6 +18,7 @@ type OllamaClient struct {
 
 type GenerateRequest struct {
 	Model  string `json:"model"`
+	System string `json:"system,omitempty"`
 	Prompt string `json:"prompt"`
 	Stream bool   `json:"stream"`
 }
@@ -44,14 +45,17 @@ func NewOllamaClient(baseURL, model string) *OllamaClient {
 	}
 }
 
-func (c *OllamaClient) Generate(ctx context.Context, prompt string) (string, error) {
+func (c *OllamaClient) Generate(ctx context.Context, system, prompt string) (string, error) {
 	u, err := url.JoinPath(c.BaseURL, "/api/generate")
 	if err != nil {
 		return "", fmt.Errorf("invalid base URL: %w", err)
 	}
 
+	// The system field overrides the model's default system prompt, keeping
+	// instructions apart from the untrusted command data in the prompt
 	req := GenerateRequest{
 		Model:  c.Model,
+		System: system,
 		Prompt: prompt,
 		Stream: false,
 	}
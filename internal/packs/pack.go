@@ -0,0 +1,414 @@
+package packs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"parrot/internal/classify"
+	"parrot/internal/exitcode"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile names the manifest at the root of every pack.
+const ManifestFile = "pack.toml"
+
+// maxLineLength matches what the parrot prints before truncating
+const maxLineLength = 150
+
+// Manifest describes a pack and the files it ships.
+type Manifest struct {
+	Name        string            `toml:"name"`
+	Version     string            `toml:"version"`
+	Description string            `toml:"description"`
+	Author      string            `toml:"author"`
+	Files       []string          `toml:"files"`     // Line files; defaults to every .toml/.yaml/.yml file
+	Checksums   map[string]string `toml:"checksums"` // Optional "sha256:<hex>" per file
+}
+
+// Line is a roast from a pack. Empty tags match anything.
+type Line struct {
+	Text          string   `toml:"text" yaml:"text"`
+	Personalities []string `toml:"personalities" yaml:"personalities"`
+	Types         []string `toml:"types" yaml:"types"` // Also matches subtypes: "git" covers "git.push"
+	ExitCodes     []int    `toml:"exit_codes" yaml:"exit_codes"`
+	ExitClasses   []string `toml:"exit_classes" yaml:"exit_classes"` // e.g. "not_found", "killed"
+	Intensity     int      `toml:"intensity" yaml:"intensity"`       // 1-3 for mild, sarcastic, savage; used when personalities is empty
+	Weight        int      `toml:"weight" yaml:"weight"`
+
+	Pack string `toml:"-" yaml:"-"` // Set when loaded
+}
+
+type linesFile struct {
+	Lines []Line `toml:"lines" yaml:"lines"`
+}
+
+// Pack is a loaded, validated pack.
+type Pack struct {
+	Dir      string
+	Manifest Manifest
+	Lines    []Line
+}
+
+// Problem is something lint found in a pack.
+type Problem struct {
+	Error   bool // Errors block installation, warnings don't
+	File    string
+	Message string
+}
+
+func (p Problem) String() string {
+	severity := "warning"
+	if p.Error {
+		severity = "error"
+	}
+	if p.File == "" {
+		return fmt.Sprintf("%s: %s", severity, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", severity, p.File, p.Message)
+}
+
+// Placeholders are the template variables a line may use, e.g. "{executable}".
+var Placeholders = []string{"command", "executable", "subcommand", "exit_code", "signal", "meaning", "type", "suggestion", "attempts", "stuck", "duration"}
+
+var (
+	namePattern        = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	placeholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+)
+
+var intensities = map[string]int{"mild": 1, "sarcastic": 2, "savage": 3}
+
+// Load reads and validates the pack in dir.
+func Load(dir string) (*Pack, error) {
+	pack, problems := Lint(dir)
+	if err := firstError(problems); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// Lint reads the pack in dir and reports every problem found. The pack is
+// returned even when there are errors so callers can report on it.
+func Lint(dir string) (*Pack, []Problem) {
+	pack := &Pack{Dir: dir}
+	var problems []Problem
+	report := func(isError bool, file, format string, args ...interface{}) {
+		problems = append(problems, Problem{Error: isError, File: file, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if _, err := toml.DecodeFile(filepath.Join(dir, ManifestFile), &pack.Manifest); err != nil {
+		report(true, ManifestFile, "%v", err)
+		return pack, problems
+	}
+
+	manifest := pack.Manifest
+	if !namePattern.MatchString(manifest.Name) {
+		report(true, ManifestFile, "name %q must be lowercase letters, digits, '.', '_' or '-'", manifest.Name)
+	}
+	if manifest.Version == "" {
+		report(true, ManifestFile, "version is required")
+	}
+
+	files, err := lineFiles(dir, manifest)
+	if err != nil {
+		report(true, ManifestFile, "%v", err)
+		return pack, problems
+	}
+	if len(files) == 0 {
+		report(true, ManifestFile, "pack has no line files")
+	}
+
+	checksummed := make([]string, 0, len(manifest.Checksums))
+	for file := range manifest.Checksums {
+		checksummed = append(checksummed, file)
+	}
+	sort.Strings(checksummed)
+	for _, file := range checksummed {
+		expected := manifest.Checksums[file]
+		if !localPath(file) {
+			report(true, ManifestFile, "checksum for %q must name a file inside the pack", file)
+			continue
+		}
+		actual, err := fileChecksum(filepath.Join(dir, file))
+		if err != nil {
+			report(true, file, "cannot verify checksum: %v", err)
+		} else if !strings.EqualFold(expected, actual) {
+			report(true, file, "checksum mismatch: manifest says %s, file is %s", expected, actual)
+		}
+	}
+
+	seen := map[string]string{}
+	for _, file := range files {
+		lines, err := readLines(filepath.Join(dir, file))
+		if err != nil {
+			report(true, file, "%v", err)
+			continue
+		}
+		if len(lines) == 0 {
+			report(false, file, "no lines")
+		}
+
+		for i, line := range lines {
+			where := fmt.Sprintf("%s line %d", file, i+1)
+			for _, message := range lineErrors(line) {
+				report(true, where, "%s", message)
+			}
+			if len(line.Text) > maxLineLength {
+				report(false, where, "longer than %d characters and will be truncated", maxLineLength)
+			}
+			if previous, duplicate := seen[line.Text]; duplicate {
+				report(false, where, "duplicates %s", previous)
+			}
+			seen[line.Text] = where
+
+			line.Pack = manifest.Name
+			pack.Lines = append(pack.Lines, line)
+		}
+	}
+
+	return pack, problems
+}
+
+// lineFiles lists the files holding lines, as paths relative to dir
+func lineFiles(dir string, manifest Manifest) ([]string, error) {
+	if len(manifest.Files) > 0 {
+		for _, file := range manifest.Files {
+			if !localPath(file) {
+				return nil, fmt.Errorf("file %q must be a relative path inside the pack", file)
+			}
+			if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+				return nil, fmt.Errorf("listed file %q: %w", file, err)
+			}
+		}
+		return manifest.Files, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == ManifestFile {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".toml", ".yaml", ".yml":
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+func readLines(path string) ([]Line, error) {
+	var parsed linesFile
+	switch filepath.Ext(path) {
+	case ".toml":
+		if _, err := toml.DecodeFile(path, &parsed); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(content, &parsed); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file type %q (use .toml, .yaml or .yml)", filepath.Ext(path))
+	}
+	return parsed.Lines, nil
+}
+
+func lineErrors(line Line) []string {
+	var errors []string
+	if strings.TrimSpace(line.Text) == "" {
+		errors = append(errors, "text is empty")
+	}
+	for _, personality := range line.Personalities {
+		if _, known := intensities[personality]; !known {
+			errors = append(errors, fmt.Sprintf("unknown personality %q (available: %s)", personality, strings.Join(sortedPersonalities(), ", ")))
+		}
+	}
+	for _, t := range line.Types {
+		if !classify.ValidType(t) {
+			errors = append(errors, fmt.Sprintf("invalid command type %q", t))
+		}
+	}
+	for _, code := range line.ExitCodes {
+		if code < 0 || code > 255 {
+			errors = append(errors, fmt.Sprintf("exit code %d is outside 0-255", code))
+		}
+	}
+	for _, class := range line.ExitClasses {
+		if !exitcode.ValidClass(exitcode.Class(class)) {
+			errors = append(errors, fmt.Sprintf("unknown exit class %q", class))
+		}
+	}
+	if line.Intensity < 0 || line.Intensity > 3 {
+		errors = append(errors, fmt.Sprintf("intensity %d is outside 1-3", line.Intensity))
+	}
+	if line.Weight < 0 {
+		errors = append(errors, "weight cannot be negative")
+	}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(line.Text, -1) {
+		if !knownPlaceholder(match[1]) {
+			errors = append(errors, fmt.Sprintf("unknown placeholder {%s} (available: %s)", match[1], strings.Join(Placeholders, ", ")))
+		}
+	}
+	return errors
+}
+
+func sortedPersonalities() []string {
+	personalities := make([]string, 0, len(intensities))
+	for personality := range intensities {
+		personalities = append(personalities, personality)
+	}
+	sort.Strings(personalities)
+	return personalities
+}
+
+func knownPlaceholder(name string) bool {
+	for _, placeholder := range Placeholders {
+		if name == placeholder {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the line applies to a failure.
+func (l Line) Matches(personality, commandType string, exit exitcode.Info) bool {
+	if len(l.Personalities) > 0 {
+		if !contains(l.Personalities, personality) {
+			return false
+		}
+	} else if l.Intensity > 0 && l.Intensity != intensities[personality] {
+		return false
+	}
+
+	if len(l.Types) > 0 {
+		matched := false
+		for _, t := range classify.Lineage(commandType) {
+			if contains(l.Types, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if l.ExitSpecific() {
+		matched := contains(l.ExitClasses, string(exit.Class))
+		for _, code := range l.ExitCodes {
+			if code == exit.Code {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// ExitSpecific reports whether the line targets particular exit codes or classes.
+func (l Line) ExitSpecific() bool {
+	return len(l.ExitCodes) > 0 || len(l.ExitClasses) > 0
+}
+
+// HasPlaceholders reports whether the line needs rendering.
+func (l Line) HasPlaceholders() bool {
+	return placeholderPattern.MatchString(l.Text)
+}
+
+// Render fills in template variables. Unknown or missing variables are left as-is.
+func Render(text string, vars map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if value, exists := vars[placeholder[1:len(placeholder)-1]]; exists && value != "" {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// Examples returns up to n matching lines that read well without a command,
+// for use as few-shot examples in prompts.
+func Examples(lines []Line, personality, commandType string, exit exitcode.Info, n int) []string {
+	var examples []string
+	for _, line := range lines {
+		if len(examples) == n {
+			break
+		}
+		if !line.HasPlaceholders() && len(line.Text) <= maxLineLength && line.Matches(personality, commandType, exit) {
+			examples = append(examples, line.Text)
+		}
+	}
+	return examples
+}
+
+// Digest fingerprints a pack's manifest and line files.
+func Digest(pack *Pack) (string, error) {
+	files, err := lineFiles(pack.Dir, pack.Manifest)
+	if err != nil {
+		return "", err
+	}
+	files = append([]string{ManifestFile}, files...)
+	sort.Strings(files)
+
+	hash := sha256.New()
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(pack.Dir, file))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", file, len(content))
+		hash.Write(content)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func fileChecksum(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// localPath rejects absolute paths and anything escaping the pack directory
+func localPath(path string) bool {
+	return path != "" && filepath.IsLocal(path)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func firstError(problems []Problem) error {
+	for _, problem := range problems {
+		if problem.Error && problem.File != "" {
+			return fmt.Errorf("%s: %s", problem.File, problem.Message)
+		}
+		if problem.Error {
+			return fmt.Errorf("%s", problem.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package packs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"parrot/internal/config"
+)
+
+// maxArchiveSize bounds how much an archive may extract to
+const maxArchiveSize = 10 << 20
+
+// Dir is where installed packs live, one directory per pack.
+func Dir() string {
+	return filepath.Join(config.DataDir(), "packs")
+}
+
+// Install validates the pack at source (a directory or a .tar.gz/.tgz
+// archive) and copies it into Dir, replacing any installed pack of the same
+// name. If checksum is given it must match the archive's sha256, or the
+// pack's Digest for a directory. Warnings are returned alongside the pack.
+func Install(source, checksum string) (*Pack, []Problem, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir := source
+	actual := ""
+	if !info.IsDir() {
+		if !isArchive(source) {
+			return nil, nil, fmt.Errorf("%s is not a directory or .tar.gz archive", source)
+		}
+		if actual, err = fileChecksum(source); err != nil {
+			return nil, nil, err
+		}
+
+		staging, err := os.MkdirTemp("", "parrot-pack-")
+		if err != nil {
+			return nil, nil, err
+		}
+		defer os.RemoveAll(staging)
+
+		if err := extract(source, staging); err != nil {
+			return nil, nil, fmt.Errorf("extracting %s: %w", source, err)
+		}
+		if dir, err = packRoot(staging); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pack, problems := Lint(dir)
+	if err := firstError(problems); err != nil {
+		return nil, problems, err
+	}
+
+	if info.IsDir() {
+		if actual, err = Digest(pack); err != nil {
+			return nil, problems, err
+		}
+	}
+	if checksum != "" && !strings.EqualFold(normalizeChecksum(checksum), actual) {
+		return nil, problems, fmt.Errorf("checksum mismatch: expected %s, got %s", normalizeChecksum(checksum), actual)
+	}
+
+	installed, err := copyPack(pack)
+	if err != nil {
+		return nil, problems, err
+	}
+	return installed, warnings(problems), nil
+}
+
+// List loads every installed pack. Broken packs are skipped and reported in
+// the error; the returned packs are always usable.
+func List() ([]*Pack, error) {
+	entries, err := os.ReadDir(Dir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var packs []*Pack
+	var broken []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		pack, err := Load(filepath.Join(Dir(), entry.Name()))
+		if err != nil {
+			broken = append(broken, fmt.Sprintf("%s (%v)", entry.Name(), err))
+			continue
+		}
+		packs = append(packs, pack)
+	}
+
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Manifest.Name < packs[j].Manifest.Name })
+	if len(broken) > 0 {
+		return packs, fmt.Errorf("skipped broken packs: %s", strings.Join(broken, "; "))
+	}
+	return packs, nil
+}
+
+// InstalledLines returns the lines of every usable installed pack.
+func InstalledLines() []Line {
+	packs, _ := List()
+	var lines []Line
+	for _, pack := range packs {
+		lines = append(lines, pack.Lines...)
+	}
+	return lines
+}
+
+// Remove uninstalls a pack by name.
+func Remove(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid pack name %q", name)
+	}
+	dir := filepath.Join(Dir(), name)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("pack %q is not installed", name)
+	}
+	return os.RemoveAll(dir)
+}
+
+// ShortDigest abbreviates a checksum for display.
+func ShortDigest(checksum string) string {
+	digest := strings.TrimPrefix(checksum, "sha256:")
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
+func isArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// normalizeChecksum accepts a bare hex digest as well as "sha256:<hex>"
+func normalizeChecksum(checksum string) string {
+	checksum = strings.TrimSpace(checksum)
+	if !strings.Contains(checksum, ":") {
+		return "sha256:" + checksum
+	}
+	return checksum
+}
+
+func warnings(problems []Problem) []Problem {
+	var found []Problem
+	for _, problem := range problems {
+		if !problem.Error {
+			found = append(found, problem)
+		}
+	}
+	return found
+}
+
+// extract unpacks regular files and directories, refusing links and any
+// entry that would land outside dest
+func extract(archive, dest string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	var total int64
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(header.Name)
+		if !localPath(name) {
+			return fmt.Errorf("entry %q escapes the pack", header.Name)
+		}
+		target := filepath.Join(dest, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			total += header.Size
+			if total > maxArchiveSize {
+				return fmt.Errorf("archive is larger than %d bytes", maxArchiveSize)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(out, io.LimitReader(reader, header.Size))
+			out.Close()
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("entry %q is not a regular file or directory", header.Name)
+		}
+	}
+}
+
+// packRoot finds the manifest at the top of an extracted archive or inside
+// its single top-level directory
+func packRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		nested := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(nested, ManifestFile)); err == nil {
+			return nested, nil
+		}
+	}
+	return "", fmt.Errorf("archive has no %s", ManifestFile)
+}
+
+// copyPack copies the manifest and line files into Dir, swapping the new
+// directory in only once it is complete
+func copyPack(pack *Pack) (*Pack, error) {
+	files, err := lineFiles(pack.Dir, pack.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp(Dir(), ".install-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	for _, file := range append([]string{ManifestFile}, files...) {
+		content, err := os.ReadFile(filepath.Join(pack.Dir, file))
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(staging, file)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	dest := filepath.Join(Dir(), pack.Manifest.Name)
+	if err := os.RemoveAll(dest); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(staging, dest); err != nil {
+		return nil, err
+	}
+	return Load(dest)
+}
@@ -0,0 +1,242 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/config"
+)
+
+// Action says how far information about a command may travel.
+type Action string
+
+const (
+	ActionAllow        Action = "allow"         // Any backend
+	ActionLocalOnly    Action = "local-only"    // Never the API backend
+	ActionFallbackOnly Action = "fallback-only" // No LLM at all
+	ActionSilent       Action = "silent"        // No response whatsoever
+)
+
+// Input is what a policy is evaluated against.
+type Input struct {
+	Command string
+	Parsed  *cmdline.Command
+	Cwd     string
+	Type    string
+}
+
+// Decision is the outcome of evaluating a policy.
+type Decision struct {
+	Action Action
+	Rule   string // Name of the rule that applied, empty for the default
+	Reason string // Which condition matched
+}
+
+// AllowsAPI reports whether the command may be sent to the API backend.
+func (d Decision) AllowsAPI() bool {
+	return d.Action == ActionAllow
+}
+
+// AllowsLocal reports whether the command may be sent to the local backend.
+func (d Decision) AllowsLocal() bool {
+	return d.Action == ActionAllow || d.Action == ActionLocalOnly
+}
+
+type rule struct {
+	name         string
+	command      *regexp.Regexp
+	commandRegex *regexp.Regexp
+	executables  []string
+	cwd          *regexp.Regexp
+	cwdTree      *regexp.Regexp
+	commandType  *regexp.Regexp
+	action       Action
+}
+
+// builtinRules keep secret managers on the machine no matter what.
+var builtinRules = []config.PolicyRule{
+	{
+		Name:        "secret managers",
+		Executables: []string{"vault", "gpg", "gpg2", "pass", "gopass", "op", "bw", "age", "keepassxc-cli", "secret-tool"},
+		Action:      string(ActionLocalOnly),
+	},
+}
+
+// Policy evaluates user rules in order, followed by the built-in rules.
+type Policy struct {
+	rules []rule
+}
+
+// New compiles policy rules. Invalid rules are skipped and reported; the
+// policy is always usable.
+func New(cfg config.PolicyConfig) (*Policy, error) {
+	configured := cfg.Rules
+	if cfg.Builtin {
+		configured = append(append([]config.PolicyRule{}, cfg.Rules...), builtinRules...)
+	}
+
+	var rules []rule
+	var invalid []string
+	for i, configuredRule := range configured {
+		compiled, err := compileRule(configuredRule)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("rule %d (%s): %v", i+1, configuredRule.Name, err))
+			continue
+		}
+		rules = append(rules, compiled)
+	}
+
+	p := &Policy{rules: rules}
+	if len(invalid) > 0 {
+		return p, fmt.Errorf("skipped invalid policy rules: %s", strings.Join(invalid, "; "))
+	}
+	return p, nil
+}
+
+func compileRule(r config.PolicyRule) (rule, error) {
+	compiled := rule{
+		name:        r.Name,
+		executables: r.Executables,
+		action:      Action(r.Action),
+	}
+	if compiled.name == "" {
+		compiled.name = "unnamed"
+	}
+
+	switch compiled.action {
+	case ActionAllow, ActionLocalOnly, ActionFallbackOnly, ActionSilent:
+	default:
+		return rule{}, fmt.Errorf("unknown action %q", r.Action)
+	}
+
+	if r.Command == "" && r.CommandRegex == "" && len(r.Executables) == 0 && r.Cwd == "" && r.Type == "" {
+		return rule{}, fmt.Errorf("rule has nothing to match on")
+	}
+
+	var err error
+	if r.Command != "" {
+		compiled.command = GlobToRegexp(r.Command, false)
+	}
+	if r.CommandRegex != "" {
+		if compiled.commandRegex, err = regexp.Compile(r.CommandRegex); err != nil {
+			return rule{}, fmt.Errorf("invalid command_regex: %w", err)
+		}
+	}
+	if r.Cwd != "" {
+		cwd := ExpandHome(r.Cwd)
+		compiled.cwd = GlobToRegexp(cwd, true)
+		compiled.cwdTree = GlobToRegexp(strings.TrimRight(cwd, "/")+"/**", true)
+	}
+	if r.Type != "" {
+		compiled.commandType = GlobToRegexp(r.Type, false)
+	}
+	return compiled, nil
+}
+
+// Evaluate returns the decision of the first matching rule, or allow.
+func (p *Policy) Evaluate(in Input) Decision {
+	for _, r := range p.rules {
+		if reason, ok := r.matches(in); ok {
+			return Decision{Action: r.action, Rule: r.name, Reason: reason}
+		}
+	}
+	return Decision{Action: ActionAllow}
+}
+
+func (r rule) matches(in Input) (string, bool) {
+	var reasons []string
+
+	if r.command != nil {
+		if !r.command.MatchString(strings.TrimSpace(in.Command)) {
+			return "", false
+		}
+		reasons = append(reasons, "command matches glob")
+	}
+	if r.commandRegex != nil {
+		if !r.commandRegex.MatchString(in.Command) {
+			return "", false
+		}
+		reasons = append(reasons, "command matches regex")
+	}
+	if len(r.executables) > 0 {
+		executable, ok := anyExecutable(in.Parsed, r.executables)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("runs %s", executable))
+	}
+	if r.cwd != nil {
+		cwd := filepath.Clean(in.Cwd)
+		if in.Cwd == "" || !(r.cwd.MatchString(cwd) || r.cwdTree.MatchString(cwd)) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("cwd is %s", cwd))
+	}
+	if r.commandType != nil {
+		if !r.commandType.MatchString(in.Type) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("type is %s", in.Type))
+	}
+
+	return strings.Join(reasons, ", "), true
+}
+
+// anyExecutable checks every segment, so `echo x | gpg -e` is caught too
+func anyExecutable(parsed *cmdline.Command, executables []string) (string, bool) {
+	if parsed == nil {
+		return "", false
+	}
+	for _, segment := range parsed.Segments {
+		for _, executable := range executables {
+			if segment.Executable() == executable {
+				return executable, true
+			}
+		}
+	}
+	return "", false
+}
+
+// GlobToRegexp converts a glob to an anchored regexp. For paths, "*" stops at
+// "/" and "**" crosses directories; otherwise "*" matches anything.
+func GlobToRegexp(glob string, path bool) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else if path {
+				b.WriteString("[^/]*")
+			} else {
+				b.WriteString(".*")
+			}
+		case '?':
+			if path {
+				b.WriteString("[^/]")
+			} else {
+				b.WriteString(".")
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// ExpandHome replaces a leading ~ with the user's home directory.
+func ExpandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, path[1:])
+		}
+	}
+	return path
+}
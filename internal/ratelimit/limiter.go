@@ -0,0 +1,113 @@
+// Package ratelimit keeps the parrot from roasting every single failure: a
+// snooze, a minimum interval per shell session, a roast probability and a
+// burst budget that refills over time.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"parrot/internal/config"
+)
+
+// sessionTTL is how long a session's last roast is remembered after the
+// minimum interval no longer matters, so closed shells are forgotten
+const sessionTTL = 24 * time.Hour
+
+// Decision says whether a failure may be roasted and, if not, why.
+type Decision struct {
+	Allowed bool
+	Reason  string
+}
+
+// Limiter applies the frequency settings to the shared state.
+type Limiter struct {
+	minInterval time.Duration
+	probability float64
+	burst       int
+	window      time.Duration
+	rng         *rand.Rand
+}
+
+// New creates a limiter from the frequency settings. Out-of-range values are
+// clamped.
+func New(cfg config.FrequencyConfig) *Limiter {
+	return NewSeeded(cfg, time.Now().UnixNano())
+}
+
+// NewSeeded creates a limiter whose roast probability is deterministic.
+func NewSeeded(cfg config.FrequencyConfig, seed int64) *Limiter {
+	return &Limiter{
+		minInterval: time.Duration(max(cfg.MinInterval, 0)) * time.Second,
+		probability: math.Min(math.Max(cfg.Probability, 0), 1),
+		burst:       max(cfg.Burst, 0),
+		window:      time.Duration(max(cfg.BurstWindow, 1)) * time.Second,
+		rng:         rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Allow decides whether session may be roasted now. An allowed roast is
+// recorded in state and spends from the burst budget.
+func (l *Limiter) Allow(state *State, session string, now time.Time) Decision {
+	l.refill(state, now)
+	l.forgetSessions(state, now)
+
+	if snoozed, left := state.Snoozed(now); snoozed {
+		if left == 0 {
+			return Decision{Reason: "quiet until 'parrot snooze off'"}
+		}
+		return Decision{Reason: fmt.Sprintf("snoozed for another %s", Round(left))}
+	}
+	if last, ok := state.LastRoast[session]; ok && now.Sub(last) < l.minInterval {
+		return Decision{Reason: fmt.Sprintf("last roast in this session was %s ago (min_interval %s)", Round(now.Sub(last)), l.minInterval)}
+	}
+	if l.probability < 1 && l.rng.Float64() >= l.probability {
+		return Decision{Reason: fmt.Sprintf("skipped by chance (probability %.2f)", l.probability)}
+	}
+	if l.burst > 0 && state.Tokens < 1 {
+		return Decision{Reason: fmt.Sprintf("burst budget of %d per %s used up", l.burst, l.window)}
+	}
+
+	if l.burst > 0 {
+		state.Tokens--
+	}
+	if state.LastRoast == nil {
+		state.LastRoast = map[string]time.Time{}
+	}
+	state.LastRoast[session] = now
+	return Decision{Allowed: true}
+}
+
+// refill tops up the burst budget in proportion to the time since the last
+// refill, so a full budget takes the whole window to come back
+func (l *Limiter) refill(state *State, now time.Time) {
+	if l.burst == 0 {
+		return
+	}
+	if state.Refilled.IsZero() {
+		state.Tokens = float64(l.burst)
+	} else if elapsed := now.Sub(state.Refilled); elapsed > 0 {
+		state.Tokens += float64(l.burst) * elapsed.Seconds() / l.window.Seconds()
+	}
+	state.Tokens = math.Min(state.Tokens, float64(l.burst))
+	state.Refilled = now
+}
+
+// forgetSessions drops sessions whose last roast no longer matters
+func (l *Limiter) forgetSessions(state *State, now time.Time) {
+	for session, last := range state.LastRoast {
+		if now.Sub(last) > l.minInterval+sessionTTL {
+			delete(state.LastRoast, session)
+		}
+	}
+}
+
+// Round shortens a duration for display, e.g. 24m13s.
+func Round(d time.Duration) time.Duration {
+	if d >= time.Minute {
+		return d.Round(time.Second)
+	}
+	return d.Round(100 * time.Millisecond)
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin
+
+package ratelimit
+
+import (
+	"os"
+	"syscall"
+)
+
+// lock takes an exclusive lock on file, waiting for other parrots to finish
+func lock(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+// unlock releases the lock taken by lock
+func unlock(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
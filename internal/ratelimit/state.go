@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"parrot/internal/config"
+)
+
+// State is what the limiter remembers between runs. It is shared by all of a
+// user's shell sessions.
+type State struct {
+	SnoozeUntil time.Time            `json:"snooze_until,omitzero"`
+	Quiet       bool                 `json:"quiet,omitempty"`      // Snoozed until woken up
+	LastRoast   map[string]time.Time `json:"last_roast,omitempty"` // By session ID
+	Tokens      float64              `json:"tokens"`               // Burst budget left
+	Refilled    time.Time            `json:"refilled,omitzero"`    // When Tokens was last topped up
+}
+
+// Snoozed reports whether the parrot has been told to keep quiet, and for
+// how much longer. The duration is zero when quiet until woken.
+func (s State) Snoozed(now time.Time) (bool, time.Duration) {
+	if s.Quiet {
+		return true, 0
+	}
+	if now.Before(s.SnoozeUntil) {
+		return true, s.SnoozeUntil.Sub(now)
+	}
+	return false, 0
+}
+
+// Path returns the limiter state file of the current user.
+func Path() string {
+	return filepath.Join(config.StateDir(), "ratelimit.json")
+}
+
+// Load reads the state without locking it, for display. A missing file is
+// an empty state.
+func Load(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, fmt.Errorf("failed to read rate limit state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("failed to parse rate limit state: %w", err)
+	}
+	return state, nil
+}
+
+// Update changes the state under an exclusive lock, so parrots running in
+// the background for several sessions don't overwrite each other. The state
+// is only saved when update returns nil.
+func Update(path string, update func(*State) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	lockFile, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %w", err)
+	}
+	defer lockFile.Close()
+	if err := lock(lockFile); err != nil {
+		return fmt.Errorf("failed to lock rate limit state: %w", err)
+	}
+	defer unlock(lockFile)
+
+	// A corrupt file is replaced rather than blocking roasts forever
+	state, _ := Load(path)
+	if err := update(&state); err != nil {
+		return err
+	}
+	return save(path, state)
+}
+
+// save writes the state atomically
+func save(path string, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode rate limit state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".ratelimit-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to save rate limit state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save rate limit state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save rate limit state: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
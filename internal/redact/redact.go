@@ -0,0 +1,136 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces every detected secret.
+const Mask = "[REDACTED]"
+
+// Match records one masked secret. Preview shows only enough of the original
+// to recognise it in debug output.
+type Match struct {
+	Detector string
+	Preview  string
+}
+
+func (m Match) String() string {
+	return fmt.Sprintf("%s (%s)", m.Detector, m.Preview)
+}
+
+// detector masks the "secret" group of its pattern, or the whole match when
+// the pattern has no such group.
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var builtinDetectors = []detector{
+	{"private_key", regexp.MustCompile(`(?s)(?P<secret>-----BEGIN [A-Z ]*PRIVATE KEY-----.*?(-----END [A-Z ]*PRIVATE KEY-----|$))`)},
+	{"authorization_header", regexp.MustCompile(`(?i)authorization:\s*(?:bearer|basic|token|digest)\s+(?P<secret>[^\s'"]+)`)},
+	{"url_credentials", regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@'"]+:(?P<secret>[^/\s@'"]+)@`)},
+	{"aws_access_key", regexp.MustCompile(`\b(?P<secret>(?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"github_token", regexp.MustCompile(`\b(?P<secret>(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,}))\b`)},
+	{"api_key", regexp.MustCompile(`\b(?P<secret>sk-[A-Za-z0-9_-]{20,})`)},
+	{"slack_token", regexp.MustCompile(`\b(?P<secret>xox[abprs]-[A-Za-z0-9-]{10,})\b`)},
+	{"google_api_key", regexp.MustCompile(`\b(?P<secret>AIza[0-9A-Za-z_-]{35})\b`)},
+	{"stripe_key", regexp.MustCompile(`\b(?P<secret>[rs]k_(?:live|test)_[A-Za-z0-9]{16,})\b`)},
+	{"jwt", regexp.MustCompile(`\b(?P<secret>eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,})`)},
+	{"secret_variable", regexp.MustCompile(`(?i)\b[A-Z0-9_]*(?:SECRET|TOKEN|PASSWORD|PASSWD|API_?KEY|ACCESS_KEY|PRIVATE_KEY|CREDENTIALS?)[A-Z0-9_]*=(?P<secret>"[^"]*"|'[^']*'|[^\s'"&]+)`)},
+	{"password_flag", regexp.MustCompile(`(?i)(?:^|\s)--?(?:password|passwd|pass|token|secret|api-key|apikey|access-token|auth-token)[= ](?P<secret>"[^"]*"|'[^']*'|[^\s'"]+)`)},
+	{"mysql_password", regexp.MustCompile(`\b(?:mysql|mysqldump|mysqladmin|mariadb)\b[^|;&]*?\s-p(?P<secret>[^\s'"]+)`)},
+	{"query_secret", regexp.MustCompile(`(?i)[?&](?:token|key|api_key|apikey|secret|password|sig|signature|access_token)=(?P<secret>[^&\s'"#]+)`)},
+}
+
+// Marker matches, ignoring case, wherever one of the built-in detectors
+// might find a secret, and sometimes where none would. It sticks to the
+// regular expression syntax every supported shell understands, so the hooks
+// can leave such commands out of the session transcript before parrot ever
+// sees them.
+const Marker = `-----begin [a-z ]*private key|authorization:|://[^/ :@]+:[^/ @]+@|(akia|asia)[0-9a-z]{16}|` +
+	`gh[pousr]_[0-9a-z]{36}|github_pat_|sk-[0-9a-z_-]{20}|xox[abprs]-|aiza[0-9a-z_-]{35}|[rs]k_(live|test)_|` +
+	`eyj[0-9a-z_-]{8,}[.]|(secret|token|password|passwd|api_?key|access_key|private_key|credentials?)[0-9a-z_]*=|` +
+	`-(password|passwd|pass|token|secret|api-key|apikey|access-token|auth-token)[= ]|` +
+	`(mysql|mysqldump|mysqladmin|mariadb) .*-p[^ ]|[?&](token|key|api_key|apikey|secret|password|sig|signature|access_token)=`
+
+// Redactor masks secrets using the built-in detectors plus user patterns.
+type Redactor struct {
+	detectors []detector
+}
+
+// New builds a redactor with the built-in detectors and extra user regexes.
+// Invalid user patterns are skipped and reported; the redactor is always usable.
+func New(userPatterns []string) (*Redactor, error) {
+	detectors := append([]detector{}, builtinDetectors...)
+
+	var invalid []string
+	for i, pattern := range userPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("pattern %d: %v", i+1, err))
+			continue
+		}
+		detectors = append(detectors, detector{name: fmt.Sprintf("user_pattern_%d", i+1), pattern: re})
+	}
+
+	redactor := &Redactor{detectors: detectors}
+	if len(invalid) > 0 {
+		return redactor, fmt.Errorf("skipped invalid redaction patterns: %s", strings.Join(invalid, "; "))
+	}
+	return redactor, nil
+}
+
+var defaultRedactor, _ = New(nil)
+
+// String masks secrets using only the built-in detectors.
+func String(text string) string {
+	redacted, _ := defaultRedactor.Redact(text)
+	return redacted
+}
+
+// Redact masks every detected secret and reports what was masked.
+func (r *Redactor) Redact(text string) (string, []Match) {
+	var matches []Match
+	for _, d := range r.detectors {
+		text = d.replace(text, &matches)
+	}
+	return text, matches
+}
+
+func (d detector) replace(text string, matches *[]Match) string {
+	secretGroup := d.pattern.SubexpIndex("secret")
+
+	var out strings.Builder
+	last := 0
+	for _, loc := range d.pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if secretGroup > 0 && loc[2*secretGroup] >= 0 {
+			start, end = loc[2*secretGroup], loc[2*secretGroup+1]
+		}
+		secret := text[start:end]
+		if secret == "" || secret == Mask || strings.Contains(secret, Mask) {
+			continue
+		}
+
+		out.WriteString(text[last:start])
+		out.WriteString(Mask)
+		last = end
+		*matches = append(*matches, Match{Detector: d.name, Preview: preview(secret)})
+	}
+	if last == 0 {
+		return text
+	}
+	out.WriteString(text[last:])
+	return out.String()
+}
+
+// preview keeps a short prefix so debug output is useful without leaking the secret
+func preview(secret string) string {
+	runes := []rune(secret)
+	if len(runes) <= 8 {
+		return fmt.Sprintf("%d chars", len(runes))
+	}
+	return fmt.Sprintf("%s…, %d chars", string(runes[:4]), len(runes))
+}
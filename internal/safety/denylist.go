@@ -0,0 +1,261 @@
+// Package safety decides whether parrot may run a command on the user's
+// behalf. Suggestions come from rules and models, so anything that can
+// destroy data or take a machine down is refused outright; the user can
+// still run it by hand.
+package safety
+
+import (
+	"regexp"
+	"strings"
+
+	"parrot/internal/cmdline"
+)
+
+type rule struct {
+	reason string
+	match  func(segment cmdline.Segment) bool
+}
+
+// rules are checked against every segment of a command
+var rules = []rule{
+	{"deletes files recursively", func(s cmdline.Segment) bool {
+		return s.Executable() == "rm" && hasFlag(s.Args[1:], "r", "R", "--recursive")
+	}},
+	{"formats or wipes a disk", func(s cmdline.Segment) bool {
+		exe := s.Executable()
+		return strings.HasPrefix(exe, "mkfs") || exe == "wipefs" || exe == "fdisk" || exe == "sfdisk" ||
+			exe == "parted" || exe == "shred"
+	}},
+	{"writes raw data to a device", func(s cmdline.Segment) bool {
+		return s.Executable() == "dd" && anyArg(s.Args[1:], func(arg string) bool { return strings.HasPrefix(arg, "of=/dev/") })
+	}},
+	{"changes ownership or permissions recursively from /", func(s cmdline.Segment) bool {
+		exe := s.Executable()
+		return (exe == "chmod" || exe == "chown" || exe == "chgrp") && hasFlag(s.Args[1:], "R", "--recursive") &&
+			anyArg(s.Args[1:], func(arg string) bool { return arg == "/" || arg == "/*" })
+	}},
+	{"shuts down or reboots the machine", func(s cmdline.Segment) bool {
+		switch s.Executable() {
+		case "shutdown", "reboot", "halt", "poweroff":
+			return true
+		case "init", "telinit":
+			return anyArg(s.Args[1:], func(arg string) bool { return arg == "0" || arg == "6" })
+		}
+		return false
+	}},
+	{"discards git history or uncommitted work", func(s cmdline.Segment) bool {
+		if s.Executable() != "git" {
+			return false
+		}
+		args := s.Args[1:]
+		switch s.Subcommand() {
+		case "reset":
+			return hasFlag(args, "--hard")
+		case "clean":
+			return hasFlag(args, "f", "--force")
+		case "push":
+			// A +refspec forces that one ref, a :refspec deletes it, and a lease
+			// still overwrites history
+			return hasFlag(args, "f", "--force", "--force-with-lease", "--force-if-includes", "--mirror", "--delete", "d") ||
+				anyArg(args, func(arg string) bool { return strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, ":") })
+		case "branch":
+			return hasFlag(args, "D")
+		case "checkout", "restore":
+			return anyArg(args, func(arg string) bool { return arg == "." })
+		}
+		return false
+	}},
+	{"deletes cluster or infrastructure resources", func(s cmdline.Segment) bool {
+		switch s.Executable() {
+		case "kubectl", "oc":
+			return s.Subcommand() == "delete"
+		case "helm":
+			return s.Subcommand() == "uninstall" || s.Subcommand() == "delete"
+		case "terraform", "tofu":
+			return s.Subcommand() == "destroy" || hasFlag(s.Args[1:], "-auto-approve", "--auto-approve", "-destroy")
+		case "docker", "podman":
+			return s.Subcommand() == "system" && anyArg(s.Args[2:], func(arg string) bool { return arg == "prune" })
+		}
+		return false
+	}},
+}
+
+// patterns catch what the parser doesn't model, such as redirections and
+// SQL passed as an argument
+var patterns = []struct {
+	reason string
+	re     *regexp.Regexp
+}{
+	{"writes raw data to a device", regexp.MustCompile(`>\s*/dev/(sd|hd|vd|xvd|nvme|mmcblk|disk)`)},
+	{"is a fork bomb", regexp.MustCompile(`:\s*\(\s*\)\s*\{[^}]*:\s*\|\s*:`)},
+	{"drops a database", regexp.MustCompile(`(?i)\b(drop\s+(database|schema|table)|truncate\s+table)\b`)},
+	{"deletes files recursively", regexp.MustCompile(`\bfind\b.*\s-delete\b`)},
+}
+
+// maxDepth bounds how many levels of `sh -c`, eval and find -exec are looked
+// into; anything nested deeper is refused
+const maxDepth = 4
+
+// Check reports why a command is too dangerous for parrot to run, if it is.
+func Check(command string) (string, bool) {
+	return check(command, 0)
+}
+
+func check(command string, depth int) (string, bool) {
+	if depth > maxDepth {
+		return "nests commands too deeply to check", true
+	}
+	for _, p := range patterns {
+		if p.re.MatchString(command) {
+			return p.reason, true
+		}
+	}
+
+	parsed := cmdline.Parse(command)
+	for i, segment := range parsed.Segments {
+		if len(segment.Args) == 0 {
+			continue
+		}
+		for _, r := range rules {
+			if r.match(segment) {
+				return r.reason, true
+			}
+		}
+		if shells[segment.Executable()] && pipedFromDownload(parsed, i) {
+			return "runs a downloaded script", true
+		}
+
+		// Commands run on the segment's behalf are held to the same rules
+		scripts, known := nested(segment)
+		if !known {
+			return "runs a command that can't be checked before it runs", true
+		}
+		for _, script := range scripts {
+			if reason, denied := check(script, depth+1); denied {
+				return reason, true
+			}
+		}
+	}
+	return "", false
+}
+
+// nested returns the command lines a segment runs for it: the script of
+// `sh -c` and eval, and find's -exec commands. known is false when one of
+// them is built from expansions, which only the shell running it resolves.
+func nested(s cmdline.Segment) (scripts []string, known bool) {
+	args := s.Args[1:]
+	switch exe := s.Executable(); {
+	case exe == "eval":
+		words := make([]string, 0, len(args))
+		for _, arg := range args {
+			word, ok := literal(arg)
+			if !ok {
+				return nil, false
+			}
+			words = append(words, word)
+		}
+		return []string{strings.Join(words, " ")}, true
+
+	case shells[exe] && hasFlag(args, "c"):
+		// The script is the first operand; -o and -O take an option name
+		for i := 0; i < len(args); i++ {
+			switch arg := args[i]; {
+			case arg == "-o" || arg == "+o" || arg == "-O" || arg == "+O":
+				i++
+			case strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "+"):
+			default:
+				script, ok := literal(arg)
+				return []string{script}, ok
+			}
+		}
+
+	case exe == "find":
+		for i := 0; i < len(args); i++ {
+			switch args[i] {
+			case "-exec", "-execdir", "-ok", "-okdir":
+			default:
+				continue
+			}
+			var words []string
+			for i++; i < len(args) && args[i] != ";" && args[i] != `\;` && args[i] != "+"; i++ {
+				word, ok := literal(args[i])
+				if !ok {
+					return nil, false
+				}
+				words = append(words, shellQuote(word))
+			}
+			scripts = append(scripts, strings.Join(words, " "))
+		}
+	}
+	return scripts, true
+}
+
+// literal returns the value of an argument as the parser left it. Simple
+// literals and single-quoted strings are known; anything with expansions,
+// escapes or double quotes is not.
+func literal(arg string) (string, bool) {
+	if len(arg) >= 2 && arg[0] == '\'' && arg[len(arg)-1] == '\'' && !strings.Contains(arg[1:len(arg)-1], "'") {
+		return arg[1 : len(arg)-1], true
+	}
+	if strings.ContainsAny(arg, "$`'\"\\") {
+		return "", false
+	}
+	return arg, true
+}
+
+// shellQuote single-quotes a word unless it is plainly safe, so the words of
+// a -exec command can be parsed again as a command line
+func shellQuote(word string) string {
+	if word != "" && strings.Trim(word, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-./=:,@%+") == "" {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+// shells are interpreters that run whatever is piped into them
+var shells = map[string]bool{"sh": true, "bash": true, "zsh": true, "dash": true, "ksh": true, "fish": true}
+
+// pipedFromDownload reports whether segment i reads from a curl or wget
+// earlier in the same pipeline
+func pipedFromDownload(parsed *cmdline.Command, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		previous := parsed.Segments[j]
+		if previous.Operator != "|" || previous.Pipeline != parsed.Segments[i].Pipeline {
+			return false
+		}
+		if exe := previous.Executable(); exe == "curl" || exe == "wget" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFlag reports whether args contain one of the flags. Single letters match
+// inside combined short flags, so "r" finds "-rf"; anything else must match
+// a whole argument.
+func hasFlag(args []string, flags ...string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		for _, flag := range flags {
+			if len(flag) == 1 {
+				if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.Contains(arg[1:], flag) {
+					return true
+				}
+			} else if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anyArg(args []string, match func(string) bool) bool {
+	for _, arg := range args {
+		if match(arg) {
+			return true
+		}
+	}
+	return false
+}
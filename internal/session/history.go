@@ -0,0 +1,112 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"parrot/internal/config"
+	"parrot/internal/redact"
+)
+
+// The failure history is trimmed back to keepHistoryLines once the file
+// grows past maxHistorySize.
+const (
+	maxHistorySize   = 1 << 20
+	keepHistoryLines = 4000
+)
+
+// Event is a failure reported by the hooks, whether or not the parrot
+// responded to it.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	ExitCode   int       `json:"exit_code"`
+	Type       string    `json:"type"`
+	Suppressed bool      `json:"suppressed,omitempty"`
+	Rule       string    `json:"rule,omitempty"` // Suppression rule that applied
+}
+
+// HistoryPath returns the failure history shared by all sessions.
+func HistoryPath() string {
+	return filepath.Join(config.StateDir(), "history.jsonl")
+}
+
+// AppendHistory adds an event to the history, one JSON object per line.
+// Commands are stored with secrets masked since the file is kept for long.
+func AppendHistory(path string, event Event) error {
+	event.Command = redact.String(event.Command)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	trimHistory(path)
+	return nil
+}
+
+// LoadHistory reads the events since a point in time, oldest first.
+// Malformed lines are skipped.
+func LoadHistory(path string, since time.Time) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Time.Before(since) {
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return events, nil
+}
+
+// trimHistory keeps the history from growing without bound. Errors are
+// ignored; at worst the file stays long.
+func trimHistory(path string) {
+	if info, err := os.Stat(path); err != nil || info.Size() <= maxHistorySize {
+		return
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) <= keepHistoryLines {
+		return
+	}
+
+	kept := strings.Join(lines[len(lines)-keepHistoryLines:], "\n") + "\n"
+	os.WriteFile(path, []byte(kept), 0600)
+}
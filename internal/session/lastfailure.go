@@ -0,0 +1,81 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"parrot/internal/config"
+)
+
+// Failure is the most recent failed command in a shell session, kept so
+// `parrot fix` can offer a corrected version of it.
+type Failure struct {
+	Command  string    `json:"command"`
+	ExitCode int       `json:"exit_code"`
+	Cwd      string    `json:"cwd"`
+	Time     time.Time `json:"time"`
+}
+
+// LastFailurePath returns where the current session's last failure is kept.
+// Shells without the hooks share a single record.
+func LastFailurePath() string {
+	if id := os.Getenv("PARROT_SESSION_ID"); id != "" {
+		return filepath.Join(config.StateDir(), "sessions", filepath.Base(id)+".last.json")
+	}
+	return filepath.Join(config.StateDir(), "last_failure.json")
+}
+
+// SaveLastFailure records a failure, replacing the previous one. The file is
+// written atomically so a concurrent `parrot fix` never reads half of it.
+func SaveLastFailure(path string, failure Failure) error {
+	data, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("failed to encode failure: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".last-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to save failure: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save failure: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save failure: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadLastFailure reads the recorded failure. It returns nil without an error
+// when nothing has failed yet.
+func LoadLastFailure(path string) (*Failure, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read last failure: %w", err)
+	}
+
+	var failure Failure
+	if err := json.Unmarshal(data, &failure); err != nil {
+		return nil, fmt.Errorf("failed to parse last failure: %w", err)
+	}
+	if failure.Command == "" {
+		return nil, nil
+	}
+	return &failure, nil
+}
+
+// ClearLastFailure forgets the recorded failure once it has been fixed.
+func ClearLastFailure(path string) {
+	os.Remove(path)
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"parrot/internal/config"
+)
+
+// streakGap is a pause long enough to end a streak: coming back to a command
+// after lunch is a fresh start.
+const streakGap = 30 * time.Minute
+
+// tones orders the personalities from gentlest to harshest
+var tones = []string{"mild", "sarcastic", "savage"}
+
+// Streak describes how badly a shell session is going.
+type Streak struct {
+	Failures int           // Failures in a row, of any command
+	Attempts int           // Failures of the same command since it last worked
+	Stuck    time.Duration // Time since the first of those failures
+}
+
+// CurrentStreak works out the streak from the transcript entries preceding a
+// command and whether that command failed too. When it succeeded, Attempts
+// and Stuck describe the failures it put an end to.
+func CurrentStreak(preceding []Entry, command string, failed bool, now time.Time) Streak {
+	var streak Streak
+	command = strings.TrimSpace(command)
+
+	if failed {
+		streak.Failures, streak.Attempts = 1, 1
+		last := now
+		for i := len(preceding) - 1; i >= 0; i-- {
+			entry := preceding[i]
+			if entry.ExitCode == 0 || last.Sub(entry.Time) > streakGap {
+				break
+			}
+			streak.Failures++
+			last = entry.Time
+		}
+	}
+
+	// Other commands in between, like editing a file, don't end the attempts
+	last, first := now, time.Time{}
+	for i := len(preceding) - 1; i >= 0; i-- {
+		entry := preceding[i]
+		if last.Sub(entry.Time) > streakGap {
+			break
+		}
+		last = entry.Time
+		if strings.TrimSpace(entry.Command) != command {
+			continue
+		}
+		if entry.ExitCode == 0 {
+			break
+		}
+		streak.Attempts++
+		first = entry.Time
+	}
+	if !first.IsZero() {
+		streak.Stuck = now.Sub(first)
+	}
+	return streak
+}
+
+// Escalate returns the personality to respond with: one step harsher for
+// every escalate failures after the first, or for every stuckAfter minutes
+// spent on the same command, whichever is more. Unknown personalities are
+// left alone.
+func (s Streak) Escalate(personality string, cfg config.StreakConfig) string {
+	level := slices.Index(tones, personality)
+	if level < 0 {
+		return personality
+	}
+
+	steps := 0
+	if cfg.Escalate > 0 {
+		steps = (max(s.Failures, s.Attempts, 1) - 1) / cfg.Escalate
+	}
+	if cfg.StuckAfter > 0 && s.Attempts > 1 {
+		steps = max(steps, int(s.Stuck/(time.Duration(cfg.StuckAfter)*time.Minute)))
+	}
+	return tones[min(level+steps, len(tones)-1)]
+}
@@ -0,0 +1,242 @@
+package session
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"parrot/internal/redact"
+)
+
+// maxFileLines bounds how large a session transcript may grow before it is
+// trimmed back down to keepLines entries.
+const (
+	maxFileLines = 500
+	keepLines    = 100
+)
+
+// staleAfter is how long a session's files are kept after they were last
+// written; the shell that wrote them is most likely gone.
+const staleAfter = 7 * 24 * time.Hour
+
+// Entry is a single command recorded by the shell hooks.
+type Entry struct {
+	Time     time.Time
+	ExitCode int
+	Duration time.Duration
+	Cwd      string // Empty in transcripts written by older hooks
+	Command  string
+}
+
+// TranscriptPath returns the transcript file for the current shell session,
+// as exported by the hooks. It is empty when parrot runs outside a hooked shell.
+func TranscriptPath() string {
+	return os.Getenv("PARROT_SESSION_FILE")
+}
+
+// LoadTranscript reads the last limit entries from a transcript file.
+// Each line is tab separated: unix time, exit code, duration in ms, working
+// directory, command. Lines from older hooks have no working directory.
+func LoadTranscript(path string, limit int) ([]Entry, error) {
+	if path == "" || limit <= 0 {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry, ok := parseLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+func parseLine(line string) (Entry, bool) {
+	fields := strings.SplitN(line, "\t", 5)
+	if len(fields) == 4 {
+		fields = []string{fields[0], fields[1], fields[2], "", fields[3]}
+	}
+	if len(fields) != 5 || fields[4] == "" {
+		return Entry{}, false
+	}
+
+	epoch, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return Entry{}, false
+	}
+	exitCode, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Entry{}, false
+	}
+	// Duration is optional; older shells without sub-second clocks leave it empty
+	durationMs, _ := strconv.ParseInt(fields[2], 10, 64)
+
+	return Entry{
+		Time:     time.Unix(epoch, 0),
+		ExitCode: exitCode,
+		Duration: time.Duration(durationMs) * time.Millisecond,
+		Cwd:      fields[3],
+		Command:  strings.ReplaceAll(fields[4], `\n`, "\n"),
+	}, true
+}
+
+// TrimTranscript keeps the transcript file from growing without bound and
+// masks secrets the hooks didn't recognise, such as those of user patterns.
+// The hooks keep appending meanwhile, so the trimmed copy replaces the file
+// in one rename and takes along whatever they appended in the meantime.
+func TrimTranscript(path string, redactor *redact.Redactor) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read transcript: %w", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	changed := false
+	if len(lines) > maxFileLines {
+		lines, changed = lines[len(lines)-keepLines:], true
+	}
+	for i, line := range lines {
+		if masked, matches := redactor.Redact(line); len(matches) > 0 {
+			lines[i], changed = masked, true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".transcript-*.tsv")
+	if err != nil {
+		return fmt.Errorf("failed to trim transcript: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(strings.Join(lines, "\n") + "\n")
+	if err == nil {
+		// Lines appended since the transcript was read
+		_, err = io.Copy(tmp, file)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to trim transcript: %w", err)
+	}
+
+	// Lines a hook appended to the old file while it was being replaced
+	replaced, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to trim transcript: %w", err)
+	}
+	defer replaced.Close()
+	if _, err := io.Copy(replaced, file); err != nil {
+		return fmt.Errorf("failed to trim transcript: %w", err)
+	}
+	return nil
+}
+
+// PruneSessions removes the files of other sessions that haven't been
+// written to in a week: transcripts, last failures and not-found marks.
+// Errors are ignored, like the files' other upkeep.
+func PruneSessions(path string) {
+	if path == "" {
+		return
+	}
+
+	dir := filepath.Dir(path)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	current := strings.TrimSuffix(filepath.Base(path), ".tsv")
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), current+".") {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil || time.Since(info.ModTime()) < staleAfter {
+			continue
+		}
+		os.Remove(filepath.Join(dir, file.Name()))
+	}
+}
+
+// Preceding drops the trailing entry when it is the failure currently being
+// mocked, so the transcript only shows what led up to it.
+func Preceding(entries []Entry, command string, exitCode int) []Entry {
+	if len(entries) == 0 {
+		return entries
+	}
+	last := entries[len(entries)-1]
+	if strings.TrimSpace(last.Command) == strings.TrimSpace(command) && last.ExitCode == exitCode {
+		return entries[:len(entries)-1]
+	}
+	return entries
+}
+
+// FormatTranscript renders entries as a compact, oldest-first list suitable
+// for inclusion in a prompt.
+func FormatTranscript(entries []Entry) string {
+	var b strings.Builder
+	for _, entry := range entries {
+		command := strings.ReplaceAll(redact.String(entry.Command), "\n", " ⏎ ")
+		if runes := []rune(command); len(runes) > 120 {
+			command = string(runes[:117]) + "..."
+		}
+
+		b.WriteString(fmt.Sprintf("[exit %d] %s", entry.ExitCode, command))
+		if entry.Duration > 0 {
+			b.WriteString(fmt.Sprintf(" (%s)", formatDuration(entry.Duration)))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		return d.Round(time.Second).String()
+	}
+}
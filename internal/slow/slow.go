@@ -0,0 +1,221 @@
+// Package slow decides whether a command that succeeded ran long enough for
+// the parrot to comment on the wait. Interactive programs such as editors,
+// pagers and remote shells run for as long as the user likes, so they are
+// never slow.
+package slow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/config"
+	"parrot/internal/policy"
+)
+
+// interactive lists programs whose run time is the user's own
+var interactive = []string{
+	"vi", "vim", "nvim", "nano", "emacs", "micro", "hx",
+	"less", "more", "most", "man", "info",
+	"ssh", "mosh", "telnet", "sftp", "ftp",
+	"top", "htop", "btop", "watch", "tail", "journalctl",
+	"tmux", "screen",
+	"psql", "mysql", "sqlite3", "redis-cli", "mongosh",
+	"sleep", "fzf",
+}
+
+// repls lists shells and interpreters, which are interactive when run
+// without a script or command
+var repls = []string{
+	"sh", "bash", "zsh", "fish", "nu", "xonsh",
+	"python", "python3", "ipython", "node", "irb", "ghci",
+}
+
+// gitInteractive lists git subcommands that may open an editor or a pager
+var gitInteractive = []string{"add", "commit", "rebase", "log", "diff", "show", "blame"}
+
+// Input is the command being timed.
+type Input struct {
+	Command  string
+	Parsed   *cmdline.Command
+	Type     string
+	Duration time.Duration
+}
+
+// Decision says whether a command was slow, and by which threshold.
+type Decision struct {
+	Slow      bool
+	Threshold time.Duration // Zero when the command is never slow
+	Rule      string        // Rule that set the threshold; empty for the default
+	Reason    string        // Which conditions of the rule matched
+}
+
+type rule struct {
+	name        string
+	command     *regexp.Regexp
+	executables []string
+	commandType *regexp.Regexp
+	bare        bool // Executables only match when run without arguments
+	threshold   time.Duration
+}
+
+// Checker times commands against the configured rules, the built-in rules
+// and the default threshold, in that order.
+type Checker struct {
+	enabled   bool
+	threshold time.Duration
+	rules     []rule
+}
+
+// New compiles the thresholds. Invalid rules are skipped and reported; the
+// checker is always usable.
+func New(cfg config.SlowConfig) (*Checker, error) {
+	c := &Checker{
+		enabled:   cfg.Enabled,
+		threshold: seconds(cfg.Threshold),
+	}
+
+	var invalid []string
+	for i, configured := range cfg.Rules {
+		compiled, err := compileRule(configured)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("rule %d (%s): %v", i+1, configured.Name, err))
+			continue
+		}
+		c.rules = append(c.rules, compiled)
+	}
+	if cfg.Builtin {
+		c.rules = append(c.rules, builtinRules()...)
+	}
+
+	if len(invalid) > 0 {
+		return c, fmt.Errorf("skipped invalid slow command rules: %s", strings.Join(invalid, "; "))
+	}
+	return c, nil
+}
+
+func compileRule(r config.SlowRule) (rule, error) {
+	compiled := rule{
+		name:        r.Name,
+		executables: r.Executables,
+		threshold:   seconds(r.Threshold),
+	}
+	if compiled.name == "" {
+		compiled.name = "unnamed"
+	}
+
+	if r.Command == "" && len(r.Executables) == 0 && r.Type == "" {
+		return rule{}, fmt.Errorf("rule has nothing to match on")
+	}
+	if r.Threshold < 0 {
+		return rule{}, fmt.Errorf("threshold can't be negative")
+	}
+
+	if r.Command != "" {
+		compiled.command = policy.GlobToRegexp(r.Command, false)
+	}
+	if r.Type != "" {
+		compiled.commandType = policy.GlobToRegexp(r.Type, false)
+	}
+	return compiled, nil
+}
+
+// builtinRules never time interactive programs
+func builtinRules() []rule {
+	rules := []rule{
+		{name: "built-in", executables: interactive},
+		{name: "built-in", executables: repls, bare: true},
+	}
+	for _, subcommand := range gitInteractive {
+		rules = append(rules, rule{
+			name:        "built-in",
+			commandType: policy.GlobToRegexp("git."+subcommand+"*", false),
+		})
+	}
+	return rules
+}
+
+// Check returns whether a command ran longer than its threshold. An unknown
+// duration is never slow.
+func (c *Checker) Check(in Input) Decision {
+	if !c.enabled {
+		return Decision{}
+	}
+
+	decision := Decision{Threshold: c.threshold}
+	for _, r := range c.rules {
+		if reason, ok := r.matches(in); ok {
+			decision = Decision{Threshold: r.threshold, Rule: r.name, Reason: reason}
+			break
+		}
+	}
+	decision.Slow = decision.Threshold > 0 && in.Duration >= decision.Threshold
+	return decision
+}
+
+// Shortest returns the lowest threshold any command can have, or zero when
+// none can be slow. The hooks only report successes that ran at least this
+// long.
+func (c *Checker) Shortest() time.Duration {
+	if !c.enabled {
+		return 0
+	}
+	shortest := c.threshold
+	for _, r := range c.rules {
+		if r.threshold > 0 && (shortest == 0 || r.threshold < shortest) {
+			shortest = r.threshold
+		}
+	}
+	return shortest
+}
+
+func (r rule) matches(in Input) (string, bool) {
+	var reasons []string
+
+	if r.command != nil {
+		if !r.command.MatchString(strings.TrimSpace(in.Command)) {
+			return "", false
+		}
+		reasons = append(reasons, "command matches glob")
+	}
+	if len(r.executables) > 0 {
+		executable, ok := anyExecutable(in.Parsed, r.executables, r.bare)
+		if !ok {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("runs %s", executable))
+	}
+	if r.commandType != nil {
+		if !r.commandType.MatchString(in.Type) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("type is %s", in.Type))
+	}
+
+	return strings.Join(reasons, ", "), true
+}
+
+// anyExecutable checks every segment, so `make | less` is one that waits for
+// the user too
+func anyExecutable(parsed *cmdline.Command, executables []string, bare bool) (string, bool) {
+	if parsed == nil {
+		return "", false
+	}
+	for _, segment := range parsed.Segments {
+		if bare && len(segment.Args) > 1 {
+			continue
+		}
+		for _, executable := range executables {
+			if segment.Executable() == executable {
+				return executable, true
+			}
+		}
+	}
+	return "", false
+}
+
+func seconds(n int) time.Duration {
+	return time.Duration(max(n, 0)) * time.Second
+}
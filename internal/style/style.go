@@ -0,0 +1,172 @@
+// Package style describes the shapes a roast can take: the instructions that
+// ask a model for the shape, the checks its reply must pass, and the layout
+// used to print it.
+package style
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Layouts say how a response is drawn in the terminal.
+const (
+	LayoutPlain  = "plain"  // Text after the parrot, continuation lines indented
+	LayoutBubble = "bubble" // Text in a speech bubble
+	LayoutParrot = "parrot" // Text beside an ASCII parrot
+)
+
+// Default is the style used when none, or an unknown one, is configured.
+const Default = "one-liner"
+
+// Style is one way of shaping a roast.
+type Style struct {
+	Name         string
+	Description  string
+	Lines        int    // Lines of text the response must have
+	MaxLine      int    // Longest acceptable line, in characters
+	Instructions string // Added to the prompt; empty keeps the one-liner wording
+	Layout       string
+	validate     func(lines []string) error
+}
+
+// Styles are the available response styles.
+var Styles = map[string]Style{
+	"one-liner": {
+		Name:        "one-liner",
+		Description: "a single line, the classic",
+		Lines:       1,
+		MaxLine:     150,
+		Layout:      LayoutPlain,
+	},
+	"haiku": {
+		Name:         "haiku",
+		Description:  "three lines of 5, 7 and 5 syllables",
+		Lines:        3,
+		MaxLine:      60,
+		Instructions: "Write it as a haiku instead: exactly three lines of 5, 7 and 5 syllables, one per line, with no title.",
+		Layout:       LayoutPlain,
+		validate:     syllablePattern([][2]int{{3, 7}, {5, 9}, {3, 7}}),
+	},
+	"limerick": {
+		Name:         "limerick",
+		Description:  "five rhyming lines, AABBA",
+		Lines:        5,
+		MaxLine:      70,
+		Instructions: "Write it as a limerick instead: exactly five lines rhyming AABBA, the third and fourth lines shorter, one per line, with no title.",
+		Layout:       LayoutPlain,
+		validate:     syllablePattern([][2]int{{6, 12}, {6, 12}, {3, 9}, {3, 9}, {6, 12}}),
+	},
+	"cowsay": {
+		Name:        "cowsay",
+		Description: "a one-liner in a speech bubble",
+		Lines:       1,
+		MaxLine:     150,
+		Layout:      LayoutBubble,
+	},
+	"ascii-parrot": {
+		Name:        "ascii-parrot",
+		Description: "a one-liner from an ASCII art parrot",
+		Lines:       1,
+		MaxLine:     150,
+		Layout:      LayoutParrot,
+	},
+}
+
+// Names lists the styles in the order they are offered.
+var Names = []string{"one-liner", "haiku", "limerick", "cowsay", "ascii-parrot"}
+
+// Get returns a style by name, falling back to the one-liner.
+func Get(name string) Style {
+	if s, exists := Styles[name]; exists {
+		return s
+	}
+	return Styles[Default]
+}
+
+// Valid reports whether name is a known style.
+func Valid(name string) bool {
+	_, exists := Styles[name]
+	return exists
+}
+
+// Multiline reports whether the style's text spans several lines.
+func (s Style) Multiline() bool {
+	return s.Lines > 1
+}
+
+// Validate checks that lines have the style's structure.
+func (s Style) Validate(lines []string) error {
+	if err := s.ValidateShape(lines); err != nil {
+		return err
+	}
+	if s.validate != nil {
+		return s.validate(lines)
+	}
+	return nil
+}
+
+// ValidateShape checks the number and length of lines only, for text whose
+// syllables can't be counted.
+func (s Style) ValidateShape(lines []string) error {
+	if len(lines) != s.Lines {
+		return fmt.Errorf("%s needs %d lines, got %d", s.Name, s.Lines, len(lines))
+	}
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) > s.MaxLine {
+			return fmt.Errorf("line %d is longer than %d characters", i+1, s.MaxLine)
+		}
+	}
+	return nil
+}
+
+// syllablePattern accepts lines whose rough syllable counts fall in the
+// given ranges. Counting is heuristic, so the ranges are generous.
+func syllablePattern(ranges [][2]int) func([]string) error {
+	return func(lines []string) error {
+		for i, line := range lines {
+			count := Syllables(line)
+			if count < ranges[i][0] || count > ranges[i][1] {
+				return fmt.Errorf("line %d has about %d syllables, expected %d-%d", i+1, count, ranges[i][0], ranges[i][1])
+			}
+		}
+		return nil
+	}
+}
+
+// Syllables estimates the syllables in a line of English by counting vowel
+// groups per word, ignoring a silent final "e".
+func Syllables(line string) int {
+	total := 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	}) {
+		total += wordSyllables(word)
+	}
+	return total
+}
+
+func wordSyllables(word string) int {
+	word = strings.Trim(word, "'")
+	if word == "" {
+		return 0
+	}
+	// Numbers and acronyms without vowels are read out symbol by symbol
+	if !strings.ContainsAny(word, "aeiouy") {
+		return len([]rune(word))
+	}
+
+	count, inVowels := 0, false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !inVowels {
+			count++
+		}
+		inVowels = vowel
+	}
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	return max(count, 1)
+}
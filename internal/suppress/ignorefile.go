@@ -0,0 +1,89 @@
+package suppress
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"parrot/internal/policy"
+)
+
+// IgnoreFile is the name of the per-directory suppression file.
+const IgnoreFile = ".parrotignore"
+
+// ignoreRules loads the rules of every .parrotignore from dir up to the
+// root, nearest first. A file applies to its directory and everything below
+// it. Unreadable files and invalid lines are skipped.
+func ignoreRules(dir string) []rule {
+	var rules []rule
+	for dir = filepath.Clean(dir); ; dir = filepath.Dir(dir) {
+		path := filepath.Join(dir, IgnoreFile)
+		if file, err := os.Open(path); err == nil {
+			rules = append(rules, parseIgnoreFile(path, file)...)
+			file.Close()
+		}
+		if filepath.Dir(dir) == dir {
+			return rules
+		}
+	}
+}
+
+// parseIgnoreFile reads one pattern per line, matched against the whole
+// command. Blank lines and lines starting with # are skipped.
+//
+//	npm run lint*        glob over the command
+//	/^terraform plan/    regular expression between slashes
+//	grep * :1            only when the exit code is 1
+//	:130                 any command that exits with 130
+func parseIgnoreFile(path string, file *os.File) []rule {
+	var rules []rule
+	scanner := bufio.NewScanner(file)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if r, err := parseIgnoreLine(line); err == nil {
+			r.name = fmt.Sprintf("%s:%d", path, number)
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+func parseIgnoreLine(line string) (rule, error) {
+	var r rule
+	pattern, codes := line, ""
+	if i := strings.LastIndex(line, ":"); i >= 0 && (i == 0 || line[i-1] == ' ') {
+		pattern, codes = strings.TrimSpace(line[:i]), line[i+1:]
+	}
+	if codes != "" {
+		for _, field := range strings.Split(codes, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				// Not an exit code list after all, e.g. "scp host :path"
+				pattern, r.exitCodes = line, nil
+				break
+			}
+			r.exitCodes = append(r.exitCodes, code)
+		}
+	}
+
+	switch {
+	case len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/"):
+		regex, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return rule{}, err
+		}
+		r.commandRegex = regex
+	case pattern != "":
+		r.command = policy.GlobToRegexp(pattern, false)
+	case len(r.exitCodes) == 0:
+		return rule{}, fmt.Errorf("empty pattern")
+	}
+	return r, nil
+}
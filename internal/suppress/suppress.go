@@ -0,0 +1,181 @@
+// Package suppress decides which failures the parrot should keep quiet
+// about: expected exits such as Ctrl-C or grep finding nothing, and whatever
+// the configuration or a .parrotignore file rules out.
+package suppress
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/config"
+	"parrot/internal/exitcode"
+	"parrot/internal/policy"
+)
+
+// Input is the failure being considered.
+type Input struct {
+	Command string
+	Parsed  *cmdline.Command
+	Cwd     string
+	Exit    exitcode.Info
+}
+
+// Decision says whether a failure is suppressed and why.
+type Decision struct {
+	Suppressed bool
+	Rule       string // Name of the rule that applied
+	Reason     string // Which conditions matched
+}
+
+type rule struct {
+	name         string
+	command      *regexp.Regexp
+	commandRegex *regexp.Regexp
+	executables  []string
+	exitCodes    []int
+	cwd          *regexp.Regexp
+	cwdTree      *regexp.Regexp
+}
+
+// Suppressor checks failures against the built-in rule, the configured
+// rules and any .parrotignore files, in that order.
+type Suppressor struct {
+	builtin    bool
+	ignoreFile bool
+	rules      []rule
+}
+
+// New compiles suppression rules. Invalid rules are skipped and reported;
+// the suppressor is always usable.
+func New(cfg config.SuppressConfig) (*Suppressor, error) {
+	s := &Suppressor{builtin: cfg.Builtin, ignoreFile: cfg.IgnoreFile}
+
+	var invalid []string
+	for i, configured := range cfg.Rules {
+		compiled, err := compileRule(configured)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("rule %d (%s): %v", i+1, configured.Name, err))
+			continue
+		}
+		s.rules = append(s.rules, compiled)
+	}
+
+	if len(invalid) > 0 {
+		return s, fmt.Errorf("skipped invalid suppression rules: %s", strings.Join(invalid, "; "))
+	}
+	return s, nil
+}
+
+func compileRule(r config.SuppressRule) (rule, error) {
+	compiled := rule{
+		name:        r.Name,
+		executables: r.Executables,
+		exitCodes:   r.ExitCodes,
+	}
+	if compiled.name == "" {
+		compiled.name = "unnamed"
+	}
+
+	if r.Command == "" && r.CommandRegex == "" && len(r.Executables) == 0 && len(r.ExitCodes) == 0 && r.Cwd == "" {
+		return rule{}, fmt.Errorf("rule has nothing to match on")
+	}
+
+	var err error
+	if r.Command != "" {
+		compiled.command = policy.GlobToRegexp(r.Command, false)
+	}
+	if r.CommandRegex != "" {
+		if compiled.commandRegex, err = regexp.Compile(r.CommandRegex); err != nil {
+			return rule{}, fmt.Errorf("invalid command_regex: %w", err)
+		}
+	}
+	if r.Cwd != "" {
+		cwd := policy.ExpandHome(r.Cwd)
+		compiled.cwd = policy.GlobToRegexp(cwd, true)
+		compiled.cwdTree = policy.GlobToRegexp(strings.TrimRight(cwd, "/")+"/**", true)
+	}
+	return compiled, nil
+}
+
+// Check returns whether the failure should get no response.
+func (s *Suppressor) Check(in Input) Decision {
+	if s.builtin && in.Exit.Benign {
+		return Decision{Suppressed: true, Rule: "built-in", Reason: fmt.Sprintf("exit %s is expected", in.Exit.Summary())}
+	}
+	for _, r := range s.rules {
+		if reason, ok := r.matches(in); ok {
+			return Decision{Suppressed: true, Rule: r.name, Reason: reason}
+		}
+	}
+	if s.ignoreFile && in.Cwd != "" {
+		for _, r := range ignoreRules(in.Cwd) {
+			if reason, ok := r.matches(in); ok {
+				return Decision{Suppressed: true, Rule: r.name, Reason: reason}
+			}
+		}
+	}
+	return Decision{}
+}
+
+func (r rule) matches(in Input) (string, bool) {
+	var reasons []string
+
+	if r.command != nil {
+		if !r.command.MatchString(strings.TrimSpace(in.Command)) {
+			return "", false
+		}
+		reasons = append(reasons, "command matches glob")
+	}
+	if r.commandRegex != nil {
+		if !r.commandRegex.MatchString(in.Command) {
+			return "", false
+		}
+		reasons = append(reasons, "command matches regex")
+	}
+	if len(r.executables) > 0 {
+		executable := ""
+		if in.Parsed != nil {
+			executable = in.Parsed.FailedSegment().Executable()
+		}
+		if !contains(r.executables, executable) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("runs %s", executable))
+	}
+	if len(r.exitCodes) > 0 {
+		if !containsCode(r.exitCodes, in.Exit.Code) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("exit code is %d", in.Exit.Code))
+	}
+	if r.cwd != nil {
+		cwd := filepath.Clean(in.Cwd)
+		if in.Cwd == "" || !(r.cwd.MatchString(cwd) || r.cwdTree.MatchString(cwd)) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("cwd is %s", cwd))
+	}
+
+	return strings.Join(reasons, ", "), true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
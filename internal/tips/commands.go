@@ -0,0 +1,126 @@
+package tips
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AliasesEnv names the variable the hooks use to pass the shell's alias and
+// function names, which parrot can't see from its own process.
+const AliasesEnv = "PARROT_ALIASES"
+
+// maxSimilar bounds how many near misses are offered for an unknown command
+const maxSimilar = 3
+
+// builtins are bash and zsh builtins and keywords, which aren't on $PATH
+var builtins = []string{
+	"alias", "bg", "bind", "break", "builtin", "caller", "cd", "command", "compgen", "complete",
+	"continue", "declare", "dirs", "disown", "echo", "enable", "eval", "exec", "exit", "export",
+	"false", "fc", "fg", "getopts", "hash", "help", "history", "jobs", "kill", "let", "local",
+	"logout", "mapfile", "popd", "printf", "pushd", "pwd", "read", "readonly", "return", "set",
+	"shift", "shopt", "source", "suspend", "test", "times", "trap", "true", "type", "typeset",
+	"ulimit", "umask", "unalias", "unset", "wait",
+	// zsh
+	"autoload", "bindkey", "compdef", "emulate", "functions", "print", "rehash", "setopt",
+	"unsetopt", "whence", "where", "which", "zle", "zmodload", "zstyle",
+}
+
+var (
+	knownOnce     sync.Once
+	knownCommands []string
+)
+
+// KnownCommands returns the names the shell could have run: executables on
+// $PATH, builtins, and the aliases and functions passed in by the hooks. The
+// scan happens once per process.
+func KnownCommands() []string {
+	knownOnce.Do(func() {
+		seen := map[string]bool{}
+		add := func(name string) {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				knownCommands = append(knownCommands, name)
+			}
+		}
+
+		for _, name := range builtins {
+			add(name)
+		}
+		for _, name := range strings.Fields(os.Getenv(AliasesEnv)) {
+			add(name)
+		}
+		for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if isExecutable(dir, entry) {
+					add(entry.Name())
+				}
+			}
+		}
+	})
+	return knownCommands
+}
+
+func isExecutable(dir string, entry os.DirEntry) bool {
+	if entry.IsDir() {
+		return false
+	}
+	// Follow symlinks, which is how most package managers install binaries
+	info, err := os.Stat(filepath.Join(dir, entry.Name()))
+	return err == nil && info.Mode().IsRegular() && info.Mode()&0111 != 0
+}
+
+// SimilarCommands returns the known commands nearest to an unknown name, at
+// most maxSimilar of them. Only names at the smallest distance are returned,
+// and only if that distance is a plausible typo.
+func SimilarCommands(name string) []string {
+	return nearest(name, KnownCommands())
+}
+
+func nearest(word string, candidates []string) []string {
+	limit := typoLimit(word)
+	best := limit + 1
+
+	var similar []string
+	for _, candidate := range candidates {
+		// Candidates far longer or shorter can't be within the limit
+		if abs(len(candidate)-len(word)) > limit {
+			continue
+		}
+		distance := Distance(word, candidate)
+		switch {
+		case distance == 0 || distance > best:
+		case distance < best:
+			best, similar = distance, []string{candidate}
+		default:
+			similar = append(similar, candidate)
+		}
+	}
+
+	sort.Strings(similar)
+	if len(similar) > maxSimilar {
+		similar = similar[:maxSimilar]
+	}
+	return similar
+}
+
+// typoLimit allows one edit for short words and two for longer ones
+func typoLimit(word string) int {
+	if len(word) > 4 {
+		return 2
+	}
+	return 1
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,306 @@
+package tips
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"parrot/internal/cmdline"
+	"parrot/internal/exitcode"
+)
+
+// Prefix starts the tip line in model responses.
+const Prefix = "Tip:"
+
+// Tip is a concrete suggestion for fixing a failure.
+type Tip struct {
+	Text    string // What to tell the user
+	Command string // Corrected command, if the fix is a command
+	Rule    string // Rule that produced the tip; empty for model tips
+}
+
+// Input is the failure tips are suggested for.
+type Input struct {
+	Parsed *cmdline.Command
+	Exit   exitcode.Info
+	Cwd    string // Where the command ran; empty for the current directory
+}
+
+type rule struct {
+	name    string
+	suggest func(in Input, segment cmdline.Segment) (Tip, bool)
+}
+
+// rules are tried in order; the first suggestion wins
+var rules = []rule{
+	{"command", unknownCommand},
+	{"typo", typoSubcommand},
+	{"set-upstream", setUpstream},
+	{"sudo", missingSudo},
+	{"chmod", notExecutable},
+}
+
+// Suggest returns an offline tip for the failure, if a rule recognises it.
+func Suggest(in Input) (Tip, bool) {
+	if in.Parsed == nil || len(in.Parsed.Segments) == 0 || in.Exit.Class == exitcode.ClassSuccess {
+		return Tip{}, false
+	}
+	segment := in.Parsed.FailedSegment()
+	if len(segment.Args) == 0 {
+		return Tip{}, false
+	}
+
+	for _, r := range rules {
+		if tip, ok := r.suggest(in, segment); ok {
+			tip.Rule = r.name
+			return tip, true
+		}
+	}
+	return Tip{}, false
+}
+
+// SplitResponse separates a model response into the roast and its tip line.
+func SplitResponse(response string) (string, string) {
+	roast, tip, found := strings.Cut(response, "\n"+Prefix)
+	if !found {
+		return strings.TrimSpace(response), ""
+	}
+	return strings.TrimSpace(roast), strings.TrimSpace(tip)
+}
+
+// knownSubcommands are every subcommand and built-in alias of each tool, so
+// a valid one is never taken for a typo. User-defined aliases can't be known,
+// which is why the rule also needs the tool to have failed the way it does
+// for an unknown subcommand.
+var knownSubcommands = map[string][]string{
+	"git": {"add", "am", "apply", "archive", "bisect", "blame", "branch", "bundle", "cat-file", "checkout",
+		"cherry", "cherry-pick", "citool", "clean", "clone", "commit", "config", "describe", "diff", "difftool",
+		"fetch", "format-patch", "fsck", "gc", "grep", "gui", "help", "init", "instaweb", "log", "ls-files",
+		"ls-remote", "ls-tree", "maintenance", "merge", "mergetool", "mv", "notes", "prune", "pull", "push",
+		"range-diff", "rebase", "reflog", "remote", "repack", "replace", "request-pull", "reset", "restore",
+		"rev-list", "rev-parse", "revert", "rm", "shortlog", "show", "show-branch", "sparse-checkout", "stash",
+		"status", "submodule", "switch", "tag", "whatchanged", "worktree"},
+	"docker": {"attach", "build", "builder", "buildx", "commit", "compose", "config", "container", "context",
+		"cp", "create", "diff", "events", "exec", "export", "history", "image", "images", "import", "info",
+		"init", "inspect", "kill", "load", "login", "logout", "logs", "manifest", "network", "node", "pause",
+		"plugin", "port", "ps", "pull", "push", "rename", "restart", "rm", "rmi", "run", "save", "scout",
+		"search", "secret", "service", "stack", "start", "stats", "stop", "swarm", "system", "tag", "top",
+		"trust", "unpause", "update", "version", "volume", "wait"},
+	"npm": {"access", "add", "adduser", "audit", "bugs", "c", "cache", "ci", "cit", "clean-install", "completion",
+		"config", "dedupe", "deprecate", "diff", "dist-tag", "docs", "doctor", "edit", "exec", "explain",
+		"explore", "find", "find-dupes", "fund", "help", "home", "i", "in", "info", "init", "install",
+		"install-ci-test", "install-test", "isntall", "it", "la", "link", "list", "ll", "ln", "login", "logout",
+		"ls", "outdated", "owner", "pack", "ping", "pkg", "prefix", "profile", "prune", "publish", "query", "r",
+		"rb", "rebuild", "remove", "repo", "restart", "rm", "root", "run", "run-script", "s", "sbom", "search",
+		"se", "show", "shrinkwrap", "star", "stars", "start", "stop", "t", "team", "test", "token", "tst", "un",
+		"uninstall", "unlink", "unpublish", "unstar", "up", "update", "upgrade", "v", "version", "view",
+		"whoami", "why", "x"},
+	"kubectl": {"alpha", "annotate", "api-resources", "api-versions", "apply", "attach", "auth", "autoscale",
+		"certificate", "cluster-info", "completion", "config", "cordon", "cp", "create", "debug", "delete",
+		"describe", "diff", "drain", "edit", "events", "exec", "explain", "expose", "get", "kustomize", "label",
+		"logs", "options", "patch", "plugin", "port-forward", "proxy", "replace", "rollout", "run", "scale",
+		"set", "taint", "top", "uncordon", "version", "wait"},
+	"cargo": {"add", "b", "bench", "build", "c", "check", "clean", "clippy", "config", "d", "doc", "fetch",
+		"fix", "fmt", "generate-lockfile", "help", "init", "install", "locate-project", "login", "logout",
+		"metadata", "new", "owner", "package", "pkgid", "publish", "r", "remove", "report", "rm", "run",
+		"rustc", "rustdoc", "search", "t", "test", "tree", "uninstall", "update", "vendor", "verify-project",
+		"version", "yank"},
+	"go": {"bug", "build", "clean", "doc", "env", "fix", "fmt", "generate", "get", "help", "install", "list",
+		"mod", "run", "telemetry", "test", "tool", "version", "vet", "work"},
+	"terraform": {"apply", "console", "destroy", "fmt", "force-unlock", "get", "graph", "import", "init",
+		"login", "logout", "metadata", "output", "plan", "providers", "refresh", "show", "state", "taint",
+		"test", "untaint", "validate", "version", "workspace"},
+	"helm": {"completion", "create", "del", "delete", "dep", "dependency", "env", "fetch", "get", "help",
+		"history", "inspect", "install", "lint", "list", "ls", "package", "plugin", "pull", "push", "registry",
+		"repo", "rollback", "search", "show", "status", "template", "test", "un", "uninstall", "upgrade",
+		"verify", "version"},
+}
+
+// unknownSubcommandExit is the status each tool exits with when it doesn't
+// know a subcommand, as in `git: 'stauts' is not a git command`. parrot never
+// sees the tool's output, so this is the only sign the tool itself rejected
+// the subcommand rather than failing at it.
+var unknownSubcommandExit = map[string]int{
+	"git":       1,
+	"docker":    1,
+	"npm":       1,
+	"kubectl":   1,
+	"cargo":     101,
+	"go":        2,
+	"terraform": 1,
+	"helm":      1,
+}
+
+// unknownCommand suggests the nearest known command when the shell couldn't
+// find the one typed
+func unknownCommand(in Input, segment cmdline.Segment) (Tip, bool) {
+	name := segment.Args[0]
+	if in.Exit.Code != 127 || strings.Contains(name, "/") {
+		return Tip{}, false
+	}
+
+	similar := SimilarCommands(name)
+	switch len(similar) {
+	case 0:
+		return Tip{}, false
+	case 1:
+		command, ok := segment.ReplaceArg(in.Parsed.Raw, 0, similar[0])
+		if !ok {
+			return Tip{Text: fmt.Sprintf("Did you mean: %s", similar[0])}, true
+		}
+		return Tip{Text: fmt.Sprintf("Did you mean: %s", command), Command: command}, true
+	default:
+		return Tip{Text: fmt.Sprintf("Did you mean one of: %s", strings.Join(similar, ", "))}, true
+	}
+}
+
+func typoSubcommand(in Input, segment cmdline.Segment) (Tip, bool) {
+	known, exists := knownSubcommands[segment.Executable()]
+	index := segment.SubcommandIndex()
+	if !exists || index < 0 {
+		return Tip{}, false
+	}
+	subcommand := segment.Args[index]
+	if contains(known, subcommand) {
+		return Tip{}, false
+	}
+	if code, ok := unknownSubcommandExit[segment.Executable()]; !ok || in.Exit.Code != code {
+		return Tip{}, false
+	}
+
+	best, ok := Closest(subcommand, known)
+	if !ok {
+		return Tip{}, false
+	}
+
+	command, ok := segment.ReplaceArg(in.Parsed.Raw, index, best)
+	if !ok {
+		return Tip{}, false
+	}
+	return Tip{Text: fmt.Sprintf("Did you mean: %s", command), Command: command}, true
+}
+
+// setUpstream suggests pushing with -u when a bare push failed for lack of an
+// upstream. git exits with 128 for that, but also for other fatal errors, so
+// the branch is checked for an upstream too; rejected pushes exit with 1.
+func setUpstream(in Input, segment cmdline.Segment) (Tip, bool) {
+	if segment.Executable() != "git" || len(segment.Args) < 2 || segment.Args[1] != "push" || in.Parsed.IsCompound() {
+		return Tip{}, false
+	}
+	if in.Exit.Code != 128 || hasUpstream(in.Cwd) {
+		return Tip{}, false
+	}
+
+	// Only a bare push relies on an upstream; naming a remote or branch doesn't
+	for _, arg := range segment.Args[2:] {
+		if !strings.HasPrefix(arg, "-") || arg == "-u" || arg == "--set-upstream" {
+			return Tip{}, false
+		}
+	}
+
+	command := strings.TrimSpace(in.Parsed.Raw) + " -u origin HEAD"
+	return Tip{Text: fmt.Sprintf("No upstream yet? Try: %s", command), Command: command}, true
+}
+
+// hasUpstream reports whether the branch checked out in dir tracks a remote
+// branch. Outside a repository, or without git, it reports false.
+func hasUpstream(dir string) bool {
+	check := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	check.Dir = dir
+	return check.Run() == nil
+}
+
+// missingSudo suggests sudo when the command failed for lack of permission.
+// Operations that usually need root, such as apt install, fail in plenty of
+// other ways too, and parrot can't see which, so needing root is never
+// enough on its own.
+func missingSudo(in Input, segment cmdline.Segment) (Tip, bool) {
+	if in.Parsed.IsCompound() || os.Geteuid() == 0 {
+		return Tip{}, false
+	}
+	for _, wrapper := range segment.Wrappers {
+		if wrapper == "sudo" || wrapper == "doas" {
+			return Tip{}, false
+		}
+	}
+
+	if in.Exit.Class != exitcode.ClassPermission {
+		return Tip{}, false
+	}
+
+	command := "sudo " + strings.TrimSpace(in.Parsed.Raw)
+	return Tip{Text: fmt.Sprintf("Needs root. Try: %s", command), Command: command}, true
+}
+
+func notExecutable(in Input, segment cmdline.Segment) (Tip, bool) {
+	if in.Exit.Class != exitcode.ClassNotExecutable || !strings.Contains(segment.Args[0], "/") {
+		return Tip{}, false
+	}
+
+	// The path as typed, so quotes and variables mean what they did
+	path, ok := segment.ArgSource(in.Parsed.Raw, 0)
+	if !ok {
+		return Tip{}, false
+	}
+	command := fmt.Sprintf("chmod +x %s && %s", path, strings.TrimSpace(in.Parsed.Raw))
+	return Tip{Text: fmt.Sprintf("Not executable yet. Try: %s", command), Command: command}, true
+}
+
+// Closest returns the candidate nearest to word by edit distance, if it is
+// close enough to be a plausible typo and no other candidate is as close.
+func Closest(word string, candidates []string) (string, bool) {
+	best, bestDistance, tied := "", -1, false
+	for _, candidate := range candidates {
+		distance := Distance(word, candidate)
+		switch {
+		case bestDistance == -1 || distance < bestDistance:
+			best, bestDistance, tied = candidate, distance, false
+		case distance == bestDistance:
+			tied = true
+		}
+	}
+
+	if bestDistance < 1 || bestDistance > typoLimit(word) || tied {
+		return "", false
+	}
+	return best, true
+}
+
+// Distance is the optimal string alignment distance between a and b: the
+// number of insertions, deletions, substitutions and adjacent transpositions
+// needed to turn one into the other, so "gti" is one edit from "git".
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}